@@ -0,0 +1,389 @@
+package arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/gripper"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/spatialmath"
+	rutils "go.viam.com/rdk/utils"
+	"go.viam.com/utils"
+)
+
+// ModelNameBioGripper is UFactory's BIO gripper, which sits on the same tool RS-485 bus as the
+// standard gripper.
+const ModelNameBioGripper = "bio_gripper"
+
+// BioGripperModel model for the ufactory BIO gripper.
+var BioGripperModel = family.WithModel(ModelNameBioGripper)
+
+// BIO gripper registers. The BIO gripper answers on the standard gripper's slave id and shares its
+// enable, speed, position and status registers; force is its own, and only the v2 has it.
+const (
+	bioGripperForceReg uint16 = 0x0506
+)
+
+// BIO gripper position, speed and force ranges. Positions are the gripper's own units, which follow
+// the jaw opening; the open and close defaults are the targets the UFactory SDKs use for
+// open_bio_gripper and close_bio_gripper.
+const (
+	bioGripperMaxPosition   = 150
+	bioGripperOpenPosition  = 130
+	bioGripperClosePosition = 50
+	bioGripperMaxSpeed      = 4500
+	defaultBioGripperSpeed  = 2000
+	defaultBioGripperForce  = 50
+	// bioGripperEnabledMask is the status bit the gripper sets while it is enabled.
+	bioGripperEnabledMask = 0x04
+)
+
+// BioGripperConfig config for the BIO gripper.
+type BioGripperConfig struct {
+	Arm string `json:"arm"`
+	// GripperVersion pins the BIO gripper's generation to "v1" or "v2", bypassing detection. Empty
+	// (the default) reads it from the gripper's serial block.
+	GripperVersion string `json:"gripper_version,omitempty"`
+	GripperSpeed   int    `json:"gripper_speed,omitempty"`
+	// GripperForce is the v2 grasp force as a percentage, 1-100. Ignored on a v1, which has no force
+	// register. 0 means defaultBioGripperForce.
+	GripperForce int `json:"gripper_force,omitempty"`
+	// OpenPosition and ClosePosition are the targets for Open and Grab. Nil means the SDK defaults.
+	OpenPosition  *int `json:"open_position,omitempty"`
+	ClosePosition *int `json:"close_position,omitempty"`
+}
+
+// Validate validates the config.
+func (cfg *BioGripperConfig) Validate(path string) ([]string, []string, error) {
+	if cfg.Arm == "" {
+		return nil, nil, utils.NewConfigValidationFieldRequiredError(path, "arm")
+	}
+	switch cfg.GripperVersion {
+	case "", submodelV1, submodelV2:
+	default:
+		return nil, nil, fmt.Errorf(`gripper_version must be %q or %q, got %q`, submodelV1, submodelV2, cfg.GripperVersion)
+	}
+	if cfg.GripperSpeed != 0 && (cfg.GripperSpeed < 1 || cfg.GripperSpeed > bioGripperMaxSpeed) {
+		return nil, nil, fmt.Errorf("gripper_speed must be between 1 and %d, got %d", bioGripperMaxSpeed, cfg.GripperSpeed)
+	}
+	if cfg.GripperForce != 0 && (cfg.GripperForce < 1 || cfg.GripperForce > 100) {
+		return nil, nil, fmt.Errorf("gripper_force must be between 1 and 100, got %d", cfg.GripperForce)
+	}
+	open, closed := cfg.positions()
+	for name, pos := range map[string]int{"open_position": open, "close_position": closed} {
+		if pos < 0 || pos > bioGripperMaxPosition {
+			return nil, nil, fmt.Errorf("%s must be between 0 and %d, got %d", name, bioGripperMaxPosition, pos)
+		}
+	}
+	if closed >= open {
+		return nil, nil, fmt.Errorf("close_position (%d) must be less than open_position (%d)", closed, open)
+	}
+	return []string{cfg.Arm}, nil, nil
+}
+
+// positions returns the open and close targets, with the defaults filled in.
+func (cfg *BioGripperConfig) positions() (int, int) {
+	open, closed := bioGripperOpenPosition, bioGripperClosePosition
+	if cfg.OpenPosition != nil {
+		open = *cfg.OpenPosition
+	}
+	if cfg.ClosePosition != nil {
+		closed = *cfg.ClosePosition
+	}
+	return open, closed
+}
+
+func init() {
+	resource.RegisterComponent(
+		gripper.API,
+		BioGripperModel,
+		resource.Registration[gripper.Gripper, *BioGripperConfig]{
+			Constructor: newBioGripper,
+		})
+}
+
+type myBioGripper struct {
+	resource.AlwaysRebuild
+
+	name resource.Name
+	mf   referenceframe.Model
+
+	x *xArm
+
+	moveLock sync.Mutex
+	isMoving atomic.Bool
+
+	detected          detectedGripper
+	openPos, closePos int
+	speed, force      uint16
+
+	logger logging.Logger
+}
+
+func newBioGripper(ctx context.Context, deps resource.Dependencies, config resource.Config, logger logging.Logger) (gripper.Gripper, error) {
+	newConf, err := resource.NativeConfig[*BioGripperConfig](config)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := arm.FromProvider(deps, newConf.Arm)
+	if err != nil {
+		return nil, err
+	}
+	x, err := rutils.AssertType[*xArm](a)
+	if err != nil {
+		return nil, fmt.Errorf("bio gripper: %w", err)
+	}
+
+	detected, err := resolveBioGripperVersion(ctx, x, newConf.GripperVersion, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	geoms, err := bioGripperGeometries()
+	if err != nil {
+		return nil, err
+	}
+	mf, err := makeGeometryModel(ModelNameBioGripper, geoms)
+	if err != nil {
+		return nil, fmt.Errorf("bio gripper kinematics: %w", err)
+	}
+
+	g := &myBioGripper{
+		name:     config.ResourceName(),
+		mf:       mf,
+		x:        x,
+		detected: detected,
+		speed:    defaultBioGripperSpeed,
+		force:    defaultBioGripperForce,
+		logger:   logger,
+	}
+	g.openPos, g.closePos = newConf.positions()
+	if newConf.GripperSpeed != 0 {
+		g.speed = uint16(newConf.GripperSpeed) //nolint:gosec // Validate bounds it.
+	}
+	if newConf.GripperForce != 0 {
+		g.force = uint16(newConf.GripperForce) //nolint:gosec // Validate bounds it to 1-100.
+	}
+
+	if err := g.setup(ctx); err != nil {
+		return nil, fmt.Errorf("failed to set up bio gripper: %w", err)
+	}
+	return g, nil
+}
+
+// resolveBioGripperVersion takes a pinned version at face value and otherwise reads it from the
+// serial block. Unlike the other grippers a failed probe is fatal: nothing else answers on this bus
+// the way the BIO gripper does, so a failure means it is missing or unpowered.
+func resolveBioGripperVersion(ctx context.Context, x *xArm, pinned string, logger logging.Logger) (detectedGripper, error) {
+	if pinned != "" {
+		logger.Infof("bio gripper: configured as %s, skipping detection", pinned)
+		return detectedGripper{kind: gripperKindBio, submodel: pinned}, nil
+	}
+	d, err := x.detectBioGripper(ctx)
+	if err != nil {
+		return d, fmt.Errorf("bio gripper: cannot detect the gripper: %w. Set gripper_version in the config to skip detection", err)
+	}
+	d.submodel = bioGripperSubmodel(d.version)
+	logger.Infof("bio gripper: detected %s (serial %q)", d.submodel, d.serial)
+	return d, nil
+}
+
+// bioGripperSubmodel maps the version detectBioGripper reports to a submodel label.
+func bioGripperSubmodel(version string) string {
+	if version == "2" {
+		return submodelV2
+	}
+	return submodelV1
+}
+
+// setup enables the gripper and writes its speed, and its force on a v2.
+func (g *myBioGripper) setup(ctx context.Context) error {
+	return setupBioGripper(ctx, g.x, g.detected.submodel, g.speed, g.force)
+}
+
+// setupBioGripper enables the BIO gripper on x's tool bus and writes its speed, and its force on a v2.
+// The gripper_lite uses it too, when it finds a BIO gripper on the Lite 6.
+func setupBioGripper(ctx context.Context, x *xArm, submodel string, speed, force uint16) error {
+	if err := x.enableGripper(ctx); err != nil {
+		return err
+	}
+	if err := x.setGripperSpeed(ctx, speed); err != nil {
+		return err
+	}
+	if submodel == submodelV2 {
+		return x.writeGripperRegisters(ctx, bioGripperForceReg, []uint16{force})
+	}
+	return nil
+}
+
+// moveBioGripper sends the BIO gripper on x's tool bus to goal and waits on the status register,
+// returning the status that ended the move. The gripper drops its enable after a fault or a
+// controller reset, and ignores moves until it is enabled again, so setup runs first when it has.
+func moveBioGripper(ctx context.Context, x *xArm, name resource.Name, goal int, setup func(context.Context) error) (uint16, error) {
+	status, err := x.getGripperStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status&bioGripperEnabledMask == 0 {
+		if err := setup(ctx); err != nil {
+			return 0, err
+		}
+	}
+	if err := x.setGripperPosition(ctx, uint32(goal)); err != nil { //nolint:gosec // goal is 0..150.
+		return 0, err
+	}
+	return waitForGripperStatus(ctx, x, name, gripperStatusTimeout)
+}
+
+// holdBioGripper stops the BIO gripper by making its current position the target.
+func holdBioGripper(ctx context.Context, x *xArm) error {
+	pos, err := x.getGripperPosition(ctx)
+	if err != nil {
+		return err
+	}
+	if pos < 0 {
+		return errors.New("bio gripper reported a negative position")
+	}
+	return x.setGripperPosition(ctx, uint32(pos))
+}
+
+// moveTo sends the gripper to goal and waits on the status register, returning the status that
+// ended the move.
+func (g *myBioGripper) moveTo(ctx context.Context, goal int) (uint16, error) {
+	if goal < 0 || goal > bioGripperMaxPosition {
+		return 0, fmt.Errorf("bio gripper position must be between 0 and %d, got %d", bioGripperMaxPosition, goal)
+	}
+	g.moveLock.Lock()
+	defer g.moveLock.Unlock()
+
+	g.isMoving.Store(true)
+	defer g.isMoving.Store(false)
+
+	return moveBioGripper(ctx, g.x, g.name, goal, g.setup)
+}
+
+func (g *myBioGripper) Grab(ctx context.Context, extra map[string]any) (bool, error) {
+	status, err := g.moveTo(ctx, g.closePos)
+	if err != nil {
+		return false, err
+	}
+	return status&gripperStateMask == gripperStateDetected, nil
+}
+
+func (g *myBioGripper) Open(ctx context.Context, extra map[string]any) error {
+	_, err := g.moveTo(ctx, g.openPos)
+	return err
+}
+
+// IsHoldingSomething reports the gripper's own object-detected bit.
+func (g *myBioGripper) IsHoldingSomething(ctx context.Context, extra map[string]any) (gripper.HoldingStatus, error) {
+	status, err := g.x.getGripperStatus(ctx)
+	if err != nil {
+		return gripper.HoldingStatus{}, err
+	}
+	meta := map[string]any{"status": status}
+	// Position is best-effort, as on the standard gripper.
+	if pos, err := g.x.getGripperPosition(ctx); err == nil {
+		meta["position"] = pos
+	} else {
+		g.logger.Debugf("bio gripper position read failed during IsHoldingSomething: %v", err)
+	}
+	return gripper.HoldingStatus{
+		IsHoldingSomething: status&gripperStateMask == gripperStateDetected,
+		Meta:               meta,
+	}, nil
+}
+
+func (g *myBioGripper) Name() resource.Name {
+	return g.name
+}
+
+func (g *myBioGripper) Close(ctx context.Context) error {
+	return g.Stop(ctx, nil)
+}
+
+// DoCommand reads the position with {"get": true} and moves to a position with {"set": <0-150>}.
+func (g *myBioGripper) DoCommand(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cmd["get"] == true {
+		pos, err := g.x.getGripperPosition(ctx)
+		if err != nil {
+			return nil, err
+		}
+		status, err := g.x.getGripperStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"pos": pos, "status": status, "version": g.detected.submodel}, nil
+	}
+	if posF, ok := cmd["set"].(float64); ok {
+		if _, err := g.moveTo(ctx, int(posF)); err != nil {
+			return nil, err
+		}
+		pos, err := g.x.getGripperPosition(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"position": pos}, nil
+	}
+	return map[string]any{}, nil
+}
+
+func (g *myBioGripper) IsMoving(context.Context) (bool, error) {
+	return g.isMoving.Load(), nil
+}
+
+// Stop holds the jaws where they are by making the current position the target.
+func (g *myBioGripper) Stop(ctx context.Context, extra map[string]any) error {
+	if !g.isMoving.Load() {
+		return nil
+	}
+	return holdBioGripper(ctx, g.x)
+}
+
+func (g *myBioGripper) Geometries(ctx context.Context, _ map[string]any) ([]spatialmath.Geometry, error) {
+	return bioGripperGeometries()
+}
+
+func (g *myBioGripper) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	return g.mf, nil
+}
+
+func (g *myBioGripper) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	return []referenceframe.Input{}, nil
+}
+
+func (g *myBioGripper) GoToInputs(ctx context.Context, inputs ...[]referenceframe.Input) error {
+	return nil
+}
+
+func (g *myBioGripper) Status(_ context.Context) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+
+// bioGripperGeometries — hand-authored boxes for the BIO gripper: the motor housing on the flange
+// and the jaws at their widest.
+func bioGripperGeometries() ([]spatialmath.Geometry, error) {
+	caseBoxSize := r3.Vector{X: 62, Y: 90, Z: 92}
+	caseBox, err := spatialmath.NewBox(
+		spatialmath.NewPoseFromPoint(r3.Vector{Z: caseBoxSize.Z / 2}),
+		caseBoxSize, "case-gripper")
+	if err != nil {
+		return nil, err
+	}
+	clawSize := r3.Vector{X: 30, Y: 150, Z: 48}
+	claws, err := spatialmath.NewBox(
+		spatialmath.NewPoseFromPoint(r3.Vector{Z: caseBoxSize.Z + clawSize.Z/2}),
+		clawSize, "claws")
+	if err != nil {
+		return nil, err
+	}
+	return []spatialmath.Geometry{caseBox, claws}, nil
+}
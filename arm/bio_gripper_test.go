@@ -0,0 +1,62 @@
+package arm
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestBioGripperConfigValidate(t *testing.T) {
+	pos := func(p int) *int { return &p }
+
+	deps, _, err := (&BioGripperConfig{Arm: "a"}).Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"a"})
+
+	_, _, err = (&BioGripperConfig{}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, _, err = (&BioGripperConfig{Arm: "a", GripperVersion: "g2"}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "gripper_version")
+
+	_, _, err = (&BioGripperConfig{Arm: "a", GripperSpeed: 5000}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&BioGripperConfig{Arm: "a", GripperForce: 101}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, _, err = (&BioGripperConfig{Arm: "a", OpenPosition: pos(151)}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "open_position")
+	// The close target must stay below the open one, including against a default.
+	_, _, err = (&BioGripperConfig{Arm: "a", ClosePosition: pos(140)}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&BioGripperConfig{Arm: "a", OpenPosition: pos(100), ClosePosition: pos(20)}).Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestBioGripperPositions(t *testing.T) {
+	open, closed := (&BioGripperConfig{}).positions()
+	test.That(t, open, test.ShouldEqual, bioGripperOpenPosition)
+	test.That(t, closed, test.ShouldEqual, bioGripperClosePosition)
+
+	zero := 0
+	open, closed = (&BioGripperConfig{ClosePosition: &zero}).positions()
+	test.That(t, open, test.ShouldEqual, bioGripperOpenPosition)
+	test.That(t, closed, test.ShouldEqual, 0)
+}
+
+func TestBioGripperSubmodel(t *testing.T) {
+	test.That(t, bioGripperSubmodel("1"), test.ShouldEqual, submodelV1)
+	test.That(t, bioGripperSubmodel("2"), test.ShouldEqual, submodelV2)
+}
+
+func TestBioGripperGeometries(t *testing.T) {
+	geoms, err := bioGripperGeometries()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(geoms), test.ShouldEqual, 2)
+
+	mf, err := makeGeometryModel(ModelNameBioGripper, geoms)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(mf.DoF()), test.ShouldEqual, 0)
+}
@@ -0,0 +1,80 @@
+package arm
+
+import (
+	"fmt"
+	"math"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+)
+
+// A joint that can turn more than once, such as the last wrist joint, reaches the same angle every full
+// turn, and each extra turn winds the tool cables further. `cable_ranges` gives such joints the range
+// their cables allow. The range narrows the joint's limits, as `joint_limits` does, so nothing plans or
+// moves past it, and every requested angle is resolved to the equivalent angle, a whole number of turns
+// away, that is within the range and closest to where the joint is coming from. That applies to joint
+// moves, streamed trajectories and, through the joint moves they run, to MoveToPosition plans.
+
+// cableRange is a joint's cable range in radians.
+type cableRange struct {
+	joint    int
+	min, max float64
+}
+
+func newCableRanges(cfgs []JointLimitConfig) []cableRange {
+	ranges := make([]cableRange, len(cfgs))
+	for i, c := range cfgs {
+		ranges[i] = cableRange{joint: c.Joint, min: utils.DegToRad(c.MinDegs), max: utils.DegToRad(c.MaxDegs)}
+	}
+	return ranges
+}
+
+// resolve returns the angle a whole number of turns from target that is within the range and closest to
+// from, or an error if no turn of target is within the range.
+func (r cableRange) resolve(from, target float64) (float64, error) {
+	const turn = 2 * math.Pi
+	lo := math.Ceil((r.min - target) / turn)
+	hi := math.Floor((r.max - target) / turn)
+	if lo > hi {
+		return 0, fmt.Errorf("joint %d cannot reach %.2f degrees, or any turn of it, within its cable range [%.2f, %.2f]",
+			r.joint, utils.RadToDeg(target), utils.RadToDeg(r.min), utils.RadToDeg(r.max))
+	}
+	k := math.Max(lo, math.Min(hi, math.Round((from-target)/turn)))
+	return target + k*turn, nil
+}
+
+// resolveCableWraps resolves the cable-ranged joints of each waypoint in turn, starting from start. The
+// waypoints are copied rather than changed.
+func resolveCableWraps(ranges []cableRange, start []referenceframe.Input, positions [][]referenceframe.Input) (
+	[][]referenceframe.Input, error,
+) {
+	if len(ranges) == 0 {
+		return positions, nil
+	}
+	out := make([][]referenceframe.Input, len(positions))
+	prev := start
+	for i, pos := range positions {
+		resolved, err := resolveCableWrap(ranges, prev, pos)
+		if err != nil {
+			return nil, fmt.Errorf("waypoint %d: %w", i, err)
+		}
+		out[i] = resolved
+		prev = resolved
+	}
+	return out, nil
+}
+
+// resolveCableWrap resolves the cable-ranged joints of target, coming from prev.
+func resolveCableWrap(ranges []cableRange, prev, target []referenceframe.Input) ([]referenceframe.Input, error) {
+	out := append([]referenceframe.Input(nil), target...)
+	for _, r := range ranges {
+		if r.joint >= len(out) || r.joint >= len(prev) {
+			continue
+		}
+		var err error
+		if out[r.joint], err = r.resolve(prev[r.joint], out[r.joint]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
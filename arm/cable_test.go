@@ -0,0 +1,60 @@
+package arm
+
+import (
+	"testing"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/test"
+)
+
+func TestCableRangeResolve(t *testing.T) {
+	r := newCableRanges([]JointLimitConfig{{Joint: 5, MinDegs: -270, MaxDegs: 270}})[0]
+	resolve := func(from, target float64) float64 {
+		got, err := r.resolve(utils.DegToRad(from), utils.DegToRad(target))
+		test.That(t, err, test.ShouldBeNil)
+		return utils.RadToDeg(got)
+	}
+
+	// The shorter way round is taken when it stays in range.
+	test.That(t, resolve(-5, 350), test.ShouldAlmostEqual, -10)
+	test.That(t, resolve(10, 20), test.ShouldAlmostEqual, 20)
+	test.That(t, resolve(200, -100), test.ShouldAlmostEqual, 260)
+	test.That(t, resolve(-250, 150), test.ShouldAlmostEqual, -210)
+	// The shorter way would pass the end of the range, so the joint goes the long way.
+	test.That(t, resolve(260, -80), test.ShouldAlmostEqual, -80)
+	test.That(t, resolve(-260, 80), test.ShouldAlmostEqual, 80)
+
+	narrow := newCableRanges([]JointLimitConfig{{Joint: 0, MinDegs: -90, MaxDegs: 90}})[0]
+	_, err := narrow.resolve(0, utils.DegToRad(180))
+	test.That(t, err, test.ShouldNotBeNil)
+	got, err := narrow.resolve(0, utils.DegToRad(400))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, utils.RadToDeg(got), test.ShouldAlmostEqual, 40)
+}
+
+func TestResolveCableWraps(t *testing.T) {
+	ranges := newCableRanges([]JointLimitConfig{{Joint: 1, MinDegs: -360, MaxDegs: 360}})
+	deg := func(d ...float64) []referenceframe.Input {
+		out := make([]referenceframe.Input, len(d))
+		for i, v := range d {
+			out[i] = utils.DegToRad(v)
+		}
+		return out
+	}
+
+	positions := [][]referenceframe.Input{deg(0, 170), deg(0, -170), deg(350, 10)}
+	resolved, err := resolveCableWraps(ranges, deg(0, 0), positions)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, utils.RadToDeg(resolved[0][1]), test.ShouldAlmostEqual, 170)
+	// Each waypoint is resolved from the one before it, so the joint keeps turning the same way.
+	test.That(t, utils.RadToDeg(resolved[1][1]), test.ShouldAlmostEqual, 190)
+	test.That(t, utils.RadToDeg(resolved[2][1]), test.ShouldAlmostEqual, 10)
+	// Joints without a cable range are left alone, and so are the inputs.
+	test.That(t, utils.RadToDeg(resolved[2][0]), test.ShouldAlmostEqual, 350)
+	test.That(t, utils.RadToDeg(positions[1][1]), test.ShouldAlmostEqual, -170)
+
+	unranged, err := resolveCableWraps(nil, deg(0, 0), positions)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, unranged, test.ShouldResemble, positions)
+}
@@ -43,22 +43,32 @@ var regMap = map[string]byte{
 	"ClearWarn":      0x11,
 	"SetMode":        0x13,
 	"P2PJoint":       0x17,
+	"P2PJointBlend":  0x18,
+	"JointJerk":      0x21,
 	"MoveJoints":     0x1D,
 	"ZeroJoints":     0x19,
 	"JointPos":       0x2A,
+	"LoadParam":      0x24,
 	"Sensitivity":    0x25,
 	"SetBound":       0x34,
 	"EnableBound":    0x34,
+	"ReducedTSpeed":  0x2F,
+	"ReducedJSpeed":  0x30,
+	"GetReduced":     0x31,
+	"SetReduced":     0x32,
+	"ReducedJRange":  0x3A,
 	"CurrentTorque":  0x37,
 	"FTSensorData":   0xC8,
 	"FTSensorEnable": 0xC9,
 	"FTSensorZero":   0xCE,
 	"SetEEModel":     0x4E,
 	"ServoError":     0x6A,
+	"ServoRead16":    0x66,
 	"GripperControl": 0x7C,
 	"VacuumControl":  0x7F,
 	"LoadID":         0xCC,
 	"VacuumState":    0x80,
+	"CGPIODigital":   0x83,
 }
 
 const (
@@ -97,6 +107,11 @@ type modbusConn struct {
 	addr    string // host:port
 	logger  logging.Logger
 	onReset func() // optional callback invoked after the socket is reset (used by cmdConn to clear x.started)
+	// onReconnect and onControllerError are optional callbacks used by cmdConn to record events.
+	// onReconnect runs with the lock held, so it must not send.
+	onReconnect       func()
+	onControllerError func(ctx context.Context, err error)
+	connected         bool // whether a connection has ever been made, to tell reconnects apart
 
 	lock sync.Mutex
 	conn net.Conn
@@ -116,6 +131,10 @@ func (m *modbusConn) connect(ctx context.Context) error {
 		return err
 	}
 	m.conn = c
+	if m.connected && m.onReconnect != nil {
+		m.onReconnect()
+	}
+	m.connected = true
 	return nil
 }
 
@@ -162,18 +181,16 @@ func (m *modbusConn) send(ctx context.Context, c cmd, checkError bool) (cmd, err
 			if err != nil {
 				return cmd{}, err
 			}
-			errCode := params[1]
-			if errCode == errCodeCollision {
-				// overcurrent estop has occurred, must be manually cleared by user.
-				return cmd{}, fmt.Errorf("collision caused overcurrent: ensure robot is clear of obstacles and clear error " +
-					"through UFACTORY Studio or clear_error do command")
+			// Collisions and manual mode must be cleared by the user; any other errors are
+			// cleared automatically by the driver.
+			err = decodeError(params)
+			if isServoMotorErrorCode(params[1]) {
+				err = m.withServoFaults(ctx, err)
 			}
-			// Check for manual mode error (0x25)
-			if errCode == 0x25 {
-				return cmd{}, fmt.Errorf("arm is in manual mode: use DoCommand with 'exit_manual_mode' to return to normal operation")
+			if m.onControllerError != nil {
+				m.onControllerError(ctx, err)
 			}
-			// Any other errors are cleared automatically by the driver.
-			return cmd{}, decodeError(params)
+			return cmd{}, err
 		}
 	}
 	return resp, err
@@ -238,6 +255,39 @@ func (m *modbusConn) getErrorParams(ctx context.Context) ([]byte, error) {
 	return resp.params, nil
 }
 
+// getServoErrors queries the ServoError register on this connection, for the same reason as
+// getErrorParams.
+func (m *modbusConn) getServoErrors(ctx context.Context) ([]servoError, error) {
+	c := m.newCmd(regMap["ServoError"])
+	resp, err := m.writeBytes(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return parseServoErrors(resp.params)
+}
+
+// withServoFaults adds the faulted servos and their reasons to err, which reports a controller
+// servo-motor error. The servo query is best-effort: if it fails, err is returned with a note.
+func (m *modbusConn) withServoFaults(ctx context.Context, err error) error {
+	errs, queryErr := m.getServoErrors(ctx)
+	if queryErr != nil {
+		return fmt.Errorf("%w (servo errors unavailable: %w)", err, queryErr)
+	}
+	faults := servoFaultsErr(errs)
+	if faults == nil {
+		return err
+	}
+	// The general servo motor error doesn't name a joint; take it from the servos if only one faulted.
+	var ce *ControllerError
+	if faulted := multierr.Errors(faults); errors.As(err, &ce) && ce.Joint == 0 && len(faulted) == 1 {
+		var se servoError
+		if errors.As(faulted[0], &se) {
+			ce.Joint = se.servo
+		}
+	}
+	return fmt.Errorf("%w: %w", err, faults)
+}
+
 // Thin xArm-level wrappers that route to the command connection (port 502)
 // by default. Most existing callers stay unchanged. Gripper-bus helpers
 // (those using GripperControl/RS485_RTU) call x.gripperConn.send directly via
@@ -263,31 +313,53 @@ func (x *xArm) connect(ctx context.Context) error {
 	return x.cmdConn.connect(ctx)
 }
 
-// checkServoErrors will query the individual servos for any servo-specific
-// errors. It may be useful for troubleshooting but as the SDK does not call
-// it directly ever, we probably don't need to either during normal operation.
+func (x *xArm) getServoErrors(ctx context.Context) ([]servoError, error) {
+	if x.closed.Load() {
+		return nil, errors.New("closed")
+	}
+	return x.cmdConn.getServoErrors(ctx)
+}
+
+// CheckServoErrors queries the individual servos and returns an error naming each faulted joint and
+// the reason, or nil if none are faulted.
 func (x *xArm) CheckServoErrors(ctx context.Context) error {
-	c := x.newCmd(regMap["ServoError"])
-	e, err := x.send(ctx, c, false)
+	errs, err := x.getServoErrors(ctx)
 	if err != nil {
 		return err
 	}
-	if len(e.params) < 18 {
-		return errors.New("bad servo error query response")
+	return servoFaultsErr(errs)
+}
+
+// jointServoErrors returns the error state of the arm's joints, dropping the unused and gripper servos.
+func (x *xArm) jointServoErrors(ctx context.Context) ([]servoError, error) {
+	errs, err := x.getServoErrors(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return errs[:min(x.dof, len(errs))], nil
+}
 
-	// Get error codes for all (8) servos.
-	// xArm 6 has 6, xArm 7 has 7, and plus one in the xArm gripper
-	for i := 1; i < 9; i++ {
-		errCode := e.params[i*2]
-		errMsg, isErr := servoErrorMap[errCode]
-		if isErr {
-			err = multierr.Append(err, errors.New(errMsg))
-		}
+// servoErrorsCommand handles the `get_servo_errors` DoCommand.
+func (x *xArm) servoErrorsCommand(ctx context.Context) ([]any, error) {
+	errs, err := x.jointServoErrors(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return err
+	out := make([]any, len(errs))
+	for i, e := range errs {
+		out[i] = e.toMap()
+	}
+	return out, nil
 }
+
+// checkReadyState clears any errors and warnings the recovery policy allows, and with enableMotion
+// readies the arm to move.
 func (x *xArm) checkReadyState(ctx context.Context, enableMotion bool) error {
+	return x.readyState(ctx, enableMotion, false)
+}
+
+// readyState is checkReadyState, with operator set when an operator asked for the errors to be cleared.
+func (x *xArm) readyState(ctx context.Context, enableMotion, operator bool) error {
 	// read the current arm state
 	// bit6 is 1 if there is an error
 	// bit5 is 1 if there is a warning
@@ -302,32 +374,51 @@ func (x *xArm) checkReadyState(ctx context.Context, enableMotion bool) error {
 		// we assume that if we run into an error we will need to restart the servos etc.
 		x.started.Store(-1)
 
+		ce := newControllerError(currentState[0], currentState[1], 0)
+		var armErr error = ce
+		if isServoMotorErrorCode(currentState[1]) {
+			// Read why the servo faulted before clearing the error, which clears the servo's too.
+			armErr = x.cmdConn.withServoFaults(ctx, armErr)
+			x.logger.Warnf("arm reported a servo fault: %v", armErr)
+		}
+		x.recordControllerError(ctx, armErr)
+		if err := x.autoClearAllowed(ce, operator); err != nil {
+			return fmt.Errorf("the arm is in an error state: %w: %w", armErr, err)
+		}
+
 		// we are in error state, we will attempt to clear the error
 		// if we fail we will return the error code
 		c := x.newCmd(regMap["ClearError"])
 		newState, err := x.send(ctx, c, false)
 		if err != nil {
-			return multierr.Combine(fmt.Errorf("unable to reset the error %w", errors.New(armBoxErrorMap[currentState[1]])), err)
+			return multierr.Combine(fmt.Errorf("unable to reset the error %w", armErr), err)
 		}
 		if newState.params[0]&errorState != 0 {
 			return fmt.Errorf("the arm is in an error state and couldn't be reset, check if the e-stopped is released. Error is %w ",
-				errors.New(armBoxErrorMap[currentState[1]]))
+				armErr)
 		}
-		x.logger.Debugf("arm error %s has been cleared", errors.New(armBoxErrorMap[currentState[1]]))
+		x.logger.Debugf("arm error %s has been cleared", armErr)
+		x.recordCleared(ctx, ce, operator)
 	}
 	if currentState[0]&warningState != 0 {
 		// we are in warning state, we will attempt to clear the warning
 		// if we fail we will return the warning code
+		warnErr := newControllerError(currentState[0], 0, currentState[2])
+		x.recordControllerError(ctx, warnErr)
+		if err := x.autoClearAllowed(warnErr, operator); err != nil {
+			return fmt.Errorf("the arm has a warning: %w: %w", warnErr, err)
+		}
 		c := x.newCmd(regMap["ClearWarn"])
 		newState, err := x.send(ctx, c, false)
 		if err != nil {
-			return multierr.Combine(fmt.Errorf("unable to reset the warning %w", errors.New(armBoxWarnMap[currentState[2]])), err)
+			return multierr.Combine(fmt.Errorf("unable to reset the warning %w", warnErr), err)
 		}
 		if newState.params[0]&warningState != 0 {
 			return fmt.Errorf("the arm is in an error state and couldn't be reset, check if the e-stopped is released. Error is %w ",
-				errors.New(armBoxWarnMap[currentState[2]]))
+				warnErr)
 		}
-		x.logger.Debugf("arm error %s has been cleared", errors.New(armBoxWarnMap[currentState[2]]))
+		x.logger.Debugf("arm error %s has been cleared", warnErr)
+		x.recordCleared(ctx, warnErr, operator)
 	}
 	if currentState[0]&notReadyForMotionState != 0 && enableMotion {
 		// Check if we're intentionally in manual mode - if so, don't "fix" it
@@ -335,8 +426,16 @@ func (x *xArm) checkReadyState(ctx context.Context, enableMotion bool) error {
 			x.logger.Debug("Arm is in manual mode, skipping motion ready check")
 			return nil
 		}
+		if err := x.recovery.servosBlocked(); err != nil {
+			return err
+		}
 		x.logger.Error("motion not ready will enable it")
-		return multierr.Combine(x.setMotionMode(ctx, servoMotionMode), x.setMotionState(ctx, 0))
+		if err := multierr.Combine(x.setMotionMode(ctx, servoMotionMode), x.setMotionState(ctx, 0)); err != nil {
+			return err
+		}
+	}
+	if enableMotion {
+		return x.prepareMotionAfterRecovery(ctx)
 	}
 	return nil
 }
@@ -361,8 +460,24 @@ func (x *xArm) setMotionState(ctx context.Context, state byte) error {
 func (x *xArm) setMotionMode(ctx context.Context, state byte) error {
 	c := x.newCmd(regMap["SetMode"])
 	c.params = append(c.params, state)
-	_, err := x.send(ctx, c, true)
-	return err
+	if _, err := x.send(ctx, c, true); err != nil {
+		return err
+	}
+	x.recordEvent(ctx, armEvent{Type: eventTypeModeChange, Message: fmt.Sprintf("motion mode set to %d (%s)", state, motionModeName(state))})
+	return nil
+}
+
+func motionModeName(mode byte) string {
+	switch mode {
+	case 0:
+		return "position"
+	case servoMotionMode:
+		return "servo"
+	case manualMode:
+		return "manual"
+	default:
+		return "unknown"
+	}
 }
 
 // toggleServos toggles the servos on or off.
@@ -392,6 +507,9 @@ func (x *xArm) start(ctx context.Context, direct bool) error {
 	if err := x.checkReadyState(ctx, false); err != nil {
 		return err
 	}
+	if err := x.recovery.servosBlocked(); err != nil {
+		return err
+	}
 
 	err := x.toggleServos(ctx, true)
 	if err != nil {
@@ -472,6 +590,10 @@ func (x *xArm) exitManualMode(ctx context.Context) error {
 
 // Close shuts down the arm servos and engages brakes.
 func (x *xArm) Close(ctx context.Context) error {
+	if x.workers != nil {
+		x.workers.Stop()
+	}
+
 	if x.proxyServer != nil {
 		x.stopProxy()
 	}
@@ -513,14 +635,37 @@ func (x *xArm) internalMoveThroughJointPositions(
 	positions [][]referenceframe.Input,
 	mo moveOptions,
 ) error {
+	// Refused before it interrupts a move in progress.
+	if mo.overridesErr != nil {
+		return mo.overridesErr
+	}
+	if mo.planOnly {
+		// A dry run must neither interrupt a move in progress nor clear errors, so it stops here.
+		plan, err := x.previewMove(ctx, nil, positions, mo)
+		if err != nil {
+			return err
+		}
+		x.logger.Infof("plan_only: planned %d steps over %.2fs with %d limit violations",
+			len(plan.steps), plan.duration(), plan.violationCount)
+		return plan.violationsErr()
+	}
+
 	ctx, done := x.opMgr.New(ctx)
 	defer done()
 
-	if mo.direct && len(positions) > 1 {
-		return fmt.Errorf("direct only work with 1 position, send %d", len(positions))
+	if err := x.checkReadyState(ctx, true); err != nil {
+		return err
 	}
 
-	if err := x.checkReadyState(ctx, true); err != nil {
+	var curPos []referenceframe.Input
+	if x.plansFromStart(mo) || len(x.cableRanges) > 0 {
+		var err error
+		if curPos, err = x.JointPositions(ctx, nil); err != nil {
+			return err
+		}
+	}
+	positions, err := resolveCableWraps(x.cableRanges, curPos, positions)
+	if err != nil {
 		return err
 	}
 
@@ -532,32 +677,122 @@ func (x *xArm) internalMoveThroughJointPositions(
 	}
 
 	armRawSteps := positions
-	if x.trajGen != nil {
-		curPos, err := x.JointPositions(ctx, nil)
-		if err != nil {
-			return err
-		}
-		trajSteps, err := x.createTrajGenSteps(ctx, curPos, positions)
+	if x.plansFromStart(mo) {
+		armRawSteps, _, err = x.planJointSteps(ctx, curPos, positions, mo)
 		if err != nil {
 			return err
 		}
-		// trajectory generation thinks we are already at our goal, so don't move.
-		if trajSteps == nil {
-			return nil
+		// the planner thinks we are already at our goal, so don't move.
+		if armRawSteps == nil {
+			armRawSteps = [][]referenceframe.Input{}
 		}
-		armRawSteps = trajSteps
-	} else if !mo.direct && mo.interpolate {
-		curPos, err := x.JointPositions(ctx, nil)
-		if err != nil {
+	}
+
+	// A new move interrupts the running operations, except a direct move the controller can queue
+	// behind them.
+	if !x.queuesOnController(mo) || x.started.Load() != 0 {
+		if err := waitForOperations(ctx, x.ops.cancelPending(errSupersededOperation)); err != nil {
 			return err
 		}
-		armRawSteps, err = x.createRawJointSteps(curPos, positions, mo)
-		if err != nil {
+	}
+
+	// A move that returns before the arm stops is tracked as an operation the caller can await later.
+	var op *moveOperation
+	if !mo.waitAtEnd {
+		if op, err = x.ops.begin(mo.operationID); err != nil {
 			return err
 		}
+		x.logger.Debugf("move started as operation %q", op.id)
+	}
+
+	restore, err := x.applyMoveOverrides(ctx, mo)
+	if err != nil {
+		if op != nil {
+			op.finish(err)
+		}
+		return err
 	}
 
-	return x.executeInputs(ctx, armRawSteps, mo)
+	switch {
+	case len(armRawSteps) == 0:
+	case x.queuesOnController(mo):
+		err = x.executeQueuedInputs(ctx, armRawSteps, mo)
+	case op != nil:
+		// The module paces a servo stream itself, so a move that returns early streams in the background.
+		x.runOperation(op, func(ctx context.Context) error {
+			if err := x.executeInputs(ctx, armRawSteps, mo); err != nil {
+				return err
+			}
+			return x.waitForMotionStop(ctx)
+		}, restore)
+		return nil
+	default:
+		err = x.executeInputs(ctx, armRawSteps, mo)
+	}
+
+	if op != nil && err == nil {
+		// The arm is still moving, so the overrides stay until it stops.
+		x.awaitMotionStop(op, restore)
+		return nil
+	}
+	restore()
+	if op != nil {
+		op.finish(err)
+	}
+	return err
+}
+
+// Names of the planners planJointSteps may choose, as reported by plan previews.
+const (
+	plannerTrajGenService = "trajectory_generator"
+	plannerBuiltin        = "builtin"
+	plannerSCurve         = motionProfileSCurve
+	plannerTrapezoidal    = motionProfileTrapezoidal
+	plannerController     = "controller"
+)
+
+// plansFromStart reports whether planJointSteps needs the arm's starting position, i.e. whether we
+// interpolate the move ourselves rather than sending the waypoints to the arm as they are.
+func (x *xArm) plansFromStart(mo moveOptions) bool {
+	return x.trajGen != nil || (!mo.direct && mo.interpolate)
+}
+
+// queuesOnController reports whether a move's waypoints go to the controller's command queue as
+// point-to-point commands. Only direct moves do: a move that is neither direct nor interpolated
+// still sends its waypoints as servo commands through executeInputs.
+func (x *xArm) queuesOnController(mo moveOptions) bool {
+	return mo.direct && !x.plansFromStart(mo)
+}
+
+// planJointSteps turns the waypoints of a move starting at curPos into the joint positions executeInputs
+// will send, and names the planner that produced them. Steps are nil when the arm is already at its goal.
+// When plansFromStart is false the waypoints are returned as they are and curPos is not used.
+func (x *xArm) planJointSteps(
+	ctx context.Context,
+	curPos []referenceframe.Input,
+	positions [][]referenceframe.Input,
+	mo moveOptions,
+) ([][]referenceframe.Input, string, error) {
+	var steps [][]referenceframe.Input
+	var err error
+	switch {
+	case x.trajGen != nil:
+		steps, err = x.createTrajGenSteps(ctx, curPos, positions)
+		return steps, plannerTrajGenService, err
+	case !x.plansFromStart(mo):
+		return positions, plannerController, nil
+	case x.conf.useBuiltinTrajGen():
+		steps, err = x.createBuiltinTrajGenSteps(curPos, positions, mo)
+		return steps, plannerBuiltin, err
+	case mo.profile == motionProfileSCurve:
+		steps, err = generateSCurve(
+			append([][]referenceframe.Input{curPos}, positions...), mo.speed, mo.acceleration, mo.jerks(len(curPos)), mo.moveHZ,
+		)
+		return steps, plannerSCurve, err
+	default:
+		steps, err = x.createRawJointSteps(curPos, positions, mo)
+		return steps, plannerTrapezoidal, err
+	}
 }
 
 // MoveThroughJointPositionsStreamed executes a trajectory that arrives incrementally over a channel,
@@ -586,10 +821,18 @@ func (x *xArm) MoveThroughJointPositionsStreamed(
 	// Streaming is always servo-mode; a paced setpoint feed has no meaning in point-to-point mode, so
 	// we ignore a `direct` setting from `extra`.
 	mo.direct = false
+	if mo.overridesErr != nil {
+		return mo.overridesErr
+	}
 
 	if err := x.start(ctx, false); err != nil {
 		return err
 	}
+	restore, err := x.applyMoveOverrides(ctx, mo)
+	if err != nil {
+		return err
+	}
+	defer restore()
 
 	// Point times are relative to the start of the motion; the first point is at t=0. We anchor
 	// wall-clock to the moment we send that first point and schedule every later send for `anchor`
@@ -598,6 +841,13 @@ func (x *xArm) MoveThroughJointPositionsStreamed(
 	// producer is starving us, sends immediately with no wait; the arm holds its last setpoint until
 	// we catch up. Keeping the arm fed is the caller's contract, not ours to repair.
 	var anchor time.Time
+	// Every point is checked against the one before it, and the first against where the arm really
+	// is. That read also resolves the first point's cable wrap, so a joint wound a turn past the
+	// point stays wound rather than unwinding over the trajectory.
+	prev, err := x.readJointPositions(ctx, true)
+	if err != nil {
+		return err
+	}
 	validator := newTrajectoryStreamValidator()
 
 	// Read batches until the client ends the stream or the operation is cancelled. We select on
@@ -629,10 +879,21 @@ func (x *xArm) MoveThroughJointPositionsStreamed(
 			if len(p.Positions) != x.dof {
 				return fmt.Errorf("trajectory point has %d joint positions, arm has %d DOF", len(p.Positions), x.dof)
 			}
-			// TODO(RSDK-14284): bounds-check each point against the arm's joint limits before sending it to
-			// hardware. `arm.CheckDesiredJointPositions` does a live `JointPositions` read per call, so
-			// it cannot be used per point at this cadence; RDK's unexported `checkDesiredJointPositions`
-			// is the check we want.
+			positions, err := resolveCableWrap(x.cableRanges, prev, p.Positions)
+			if err != nil {
+				return fmt.Errorf("trajectory point at %v: %w", p.Time, err)
+			}
+			p.Positions = positions
+			// `arm.CheckDesiredJointPositions` does a live `JointPositions` read per call, so it cannot
+			// be used per point at this cadence. The previous point stands in for the arm's position.
+			// The first point is never sent, but it is still checked, or it could widen the limits for
+			// the points after it.
+			if x.model != nil {
+				if err := checkJointLimits(x.model.DoF(), prev, p.Positions); err != nil {
+					return fmt.Errorf("trajectory point at %v: %w", p.Time, err)
+				}
+			}
+			prev = p.Positions
 
 			if anchor.IsZero() {
 				anchor = time.Now()
@@ -797,6 +1058,65 @@ func (x *xArm) createTrajGenSteps(
 	return steps, nil
 }
 
+// createBuiltinTrajGenSteps plans the move with the builtin time-optimal generator. The move's speed and
+// acceleration apply to every joint, tightened per joint by any limits in the trajectory_generator config.
+func (x *xArm) createBuiltinTrajGenSteps(
+	curPos []referenceframe.Input,
+	positions [][]referenceframe.Input,
+	mo moveOptions,
+) ([][]referenceframe.Input, error) {
+	tg := x.conf.TrajGen
+	velLimits, accelLimits := x.jointMotionLimits(mo, len(curPos))
+	p := totpParams{
+		velLimits:      velLimits,
+		accelLimits:    accelLimits,
+		pathTolerance:  defaultTrajGenPathToleranceDeltaRads,
+		dedupTolerance: defaultTrajGenWaypointDeduplicationToleranceRads,
+		sampleHz:       mo.moveHZ,
+	}
+	if tg.PathToleranceDeltaRads != nil {
+		p.pathTolerance = *tg.PathToleranceDeltaRads
+	}
+	if tg.WaypointDeduplicationToleranceRads != nil {
+		p.dedupTolerance = *tg.WaypointDeduplicationToleranceRads
+	}
+	for _, jerk := range tg.JointJerkLimits {
+		p.jerkLimits = append(p.jerkLimits, rutils.DegToRad(jerk))
+	}
+
+	waypoints := make([][]float64, 0, len(positions)+1)
+	waypoints = append(waypoints, curPos)
+	waypoints = append(waypoints, positions...)
+	steps, err := generateTOTP(waypoints, p)
+	if err != nil {
+		return nil, err
+	}
+	x.logger.Debugf("builtin trajectory generator produced %d samples", len(steps))
+	return steps, nil
+}
+
+// jointMotionLimits returns the velocity and acceleration limit of each of `dof` joints for a move: the
+// move's speed and acceleration, tightened by any per-joint limits configured for the builtin generator.
+func (x *xArm) jointMotionLimits(mo moveOptions, dof int) ([]float64, []float64) {
+	velLimits := make([]float64, dof)
+	accelLimits := make([]float64, dof)
+	for j := range dof {
+		velLimits[j] = mo.speed
+		accelLimits[j] = mo.acceleration
+		if !x.conf.useBuiltinTrajGen() {
+			continue
+		}
+		tg := x.conf.TrajGen
+		if j < len(tg.JointVelocityLimits) {
+			velLimits[j] = math.Min(velLimits[j], rutils.DegToRad(tg.JointVelocityLimits[j]))
+		}
+		if j < len(tg.JointAccelerationLimits) {
+			accelLimits[j] = math.Min(accelLimits[j], rutils.DegToRad(tg.JointAccelerationLimits[j]))
+		}
+	}
+	return velLimits, accelLimits
+}
+
 func (x *xArm) clampMoveOptions(val, minVal, maxVal float64, name string) float64 {
 	if val == 0 {
 		return val
@@ -971,6 +1291,93 @@ func (x *xArm) executeInputs(ctx context.Context, rawSteps [][]float64, mo moveO
 	return ctx.Err()
 }
 
+// maxQueuedCmds is how many motion commands executeQueuedInputs lets the controller hold at once. The
+// controller's command cache is finite, so we stay well below it rather than let sends fail.
+const maxQueuedCmds = 64
+
+// executeQueuedInputs sends each step as a point-to-point command that the controller queues and plans
+// itself. Rather than pacing sends ourselves as executeInputs does, we keep the controller's command queue
+// topped up and, if waitAtEnd is set, wait for it to drain. When mo.blendRadius is set, every step but the
+// last is sent with that blend radius, so the arm rounds off intermediate waypoints without stopping.
+func (x *xArm) executeQueuedInputs(ctx context.Context, steps [][]float64, mo moveOptions) error {
+	if err := x.start(ctx, true); err != nil {
+		return err
+	}
+	// The controller plans the motion itself with a jerk it keeps until the next write, and it
+	// cannot be read back to restore. So a move that asks for a jerk writes it, and one that doesn't
+	// leaves the controller's own setting, such as one made in UFactory Studio, alone.
+	if mo.jerkSet {
+		jerk := math.Inf(1)
+		for _, j := range mo.jerks(x.dof) {
+			jerk = math.Min(jerk, j)
+		}
+		if err := x.setJointJerk(ctx, jerk); err != nil {
+			return err
+		}
+	}
+
+	for i, step := range steps {
+		if err := x.waitForCmdCount(ctx, maxQueuedCmds-1); err != nil {
+			return err
+		}
+		var err error
+		if mo.blendRadius > 0 && i+1 < len(steps) {
+			err = x.sendBlendedJointStep(ctx, step, mo)
+		} else {
+			err = x.sendJointStep(ctx, step, mo)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if !mo.waitAtEnd {
+		return ctx.Err()
+	}
+	return x.waitForQueuedMotion(ctx, mo.moveHZ)
+}
+
+// waitForQueuedMotion blocks until the controller's command queue has drained and the arm has stopped.
+func (x *xArm) waitForQueuedMotion(ctx context.Context, moveHZ float64) error {
+	if err := x.waitForCmdCount(ctx, 0); err != nil {
+		return err
+	}
+	// Give the controller a tick to start the last command before we ask whether it has stopped.
+	if !utils.SelectContextOrWait(ctx, time.Duration(1000000./moveHZ)*time.Microsecond) {
+		return ctx.Err()
+	}
+	return x.waitForMotionStop(ctx)
+}
+
+// getCmdCount returns the number of motion commands queued on the controller and not yet started.
+func (x *xArm) getCmdCount(ctx context.Context) (int, error) {
+	c := x.newCmd(regMap["CmdCount"])
+	resp, err := x.send(ctx, c, true)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.params) < 3 {
+		return 0, fmt.Errorf("command count response too short: %d bytes", len(resp.params))
+	}
+	return int(binary.BigEndian.Uint16(resp.params[1:3])), nil
+}
+
+// waitForCmdCount blocks until the controller has at most n motion commands queued.
+func (x *xArm) waitForCmdCount(ctx context.Context, n int) error {
+	for {
+		count, err := x.getCmdCount(ctx)
+		if err != nil {
+			return fmt.Errorf("error getting command count waiting for the queue to drain: %w", err)
+		}
+		if count <= n {
+			return nil
+		}
+		if !utils.SelectContextOrWait(ctx, 10*time.Millisecond) {
+			return ctx.Err()
+		}
+	}
+}
+
 // sendJointStep encodes one set of joint angles as a single servo, or point-to-point, command and
 // sends it. The arm acknowledges immediately and then chases the target at up to `mo.speed` and
 // `mo.acceleration`; the caller shapes the actual motion by how it spaces successive calls in time.
@@ -980,25 +1387,47 @@ func (x *xArm) sendJointStep(ctx context.Context, step []float64, mo moveOptions
 		cName = "P2PJoint"
 	}
 	c := x.newCmd(regMap[cName])
-	jFloatBytes := make([]byte, 4)
+	c.params = x.appendJointParams(c.params, step, mo)
+	// Motion Time - not used by the arm yet
+	c.params = append(c.params, 0, 0, 0, 0)
+
+	_, err := x.send(ctx, c, true)
+	return err
+}
+
+// sendBlendedJointStep sends a point-to-point joint command that the controller may round off by up to
+// mo.blendRadius millimetres, blending into the next queued command without stopping at this one.
+func (x *xArm) sendBlendedJointStep(ctx context.Context, step []float64, mo moveOptions) error {
+	c := x.newCmd(regMap["P2PJointBlend"])
+	c.params = x.appendJointParams(c.params, step, mo)
+	c.params = binary.LittleEndian.AppendUint32(c.params, math.Float32bits(float32(mo.blendRadius)))
+
+	_, err := x.send(ctx, c, true)
+	return err
+}
+
+// appendJointParams appends the joint angles, speed and acceleration shared by every joint motion command.
+func (x *xArm) appendJointParams(params []byte, step []float64, mo moveOptions) []byte {
 	for _, jRad := range step {
-		binary.LittleEndian.PutUint32(jFloatBytes, math.Float32bits(float32(jRad)))
-		c.params = append(c.params, jFloatBytes...)
+		params = binary.LittleEndian.AppendUint32(params, math.Float32bits(float32(jRad)))
 	}
 	// xarm 6 has 6 joints, but protocol needs 7- add 4 bytes for a blank 7th joint
 	for dof := x.dof; dof < 7; dof++ {
-		c.params = append(c.params, 0, 0, 0, 0)
+		params = append(params, 0, 0, 0, 0)
 	}
 
 	// speed
-	binary.LittleEndian.PutUint32(jFloatBytes, math.Float32bits(float32(mo.speed)))
-	c.params = append(c.params, jFloatBytes...)
+	params = binary.LittleEndian.AppendUint32(params, math.Float32bits(float32(mo.speed)))
 	// acceleration
-	binary.LittleEndian.PutUint32(jFloatBytes, math.Float32bits(float32(mo.acceleration)))
-	c.params = append(c.params, jFloatBytes...)
-	// Motion Time - not used by the arm yet
-	c.params = append(c.params, 0, 0, 0, 0)
+	params = binary.LittleEndian.AppendUint32(params, math.Float32bits(float32(mo.acceleration)))
+	return params
+}
 
+// setJointJerk sets the jerk limit, in radians per second cubed, the controller uses to plan
+// point-to-point joint moves. The controller keeps it until it is set again or the arm is restarted.
+func (x *xArm) setJointJerk(ctx context.Context, jerk float64) error {
+	c := x.newCmd(regMap["JointJerk"])
+	c.params = binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(jerk)))
 	_, err := x.send(ctx, c, true)
 	return err
 }
@@ -1040,7 +1469,13 @@ func (x *xArm) MoveToPosition(ctx context.Context, pos spatialmath.Pose, extra m
 		return fmt.Errorf("xarm cannot do MoveToPosition without speficying a motion service")
 	}
 
-	_, err := x.motion.Move(
+	restore, err := x.applyMoveOverrides(ctx, x.moveOptions(nil, extra))
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	_, err = x.motion.Move(
 		ctx,
 		motion.MoveReq{
 			ComponentName: x.Name().Name,
@@ -1055,10 +1490,14 @@ func (x *xArm) JointPositions(ctx context.Context, extra map[string]any) ([]refe
 	if err := x.checkReadyState(ctx, false); err != nil {
 		return nil, err
 	}
+	return x.readJointPositions(ctx, true)
+}
 
+// readJointPositions reads the joint positions without clearing errors first.
+func (x *xArm) readJointPositions(ctx context.Context, checkError bool) ([]referenceframe.Input, error) {
 	c := x.newCmd(regMap["JointPos"])
 
-	jData, err := x.send(ctx, c, true)
+	jData, err := x.send(ctx, c, checkError)
 	if err != nil {
 		return nil, err
 	}
@@ -1083,18 +1522,22 @@ func (x *xArm) Stop(ctx context.Context, extra map[string]any) error {
 	ctx, done := x.opMgr.New(ctx)
 	defer done()
 
+	ops := x.ops.cancelPending(errStoppedOperation)
 	x.started.Store(-1)
 
 	if err := x.setMotionState(ctx, 3); err != nil {
 		return err
 	}
+	if err := waitForOperations(ctx, ops); err != nil {
+		return err
+	}
 
 	return x.start(ctx, false)
 }
 
 // IsMoving returns whether the arm is moving.
 func (x *xArm) IsMoving(ctx context.Context) (bool, error) {
-	return x.opMgr.OpRunning(), nil
+	return x.opMgr.OpRunning() || len(x.ops.pending()) > 0, nil
 }
 
 // setupGripper puts the gripper in the state a plain Fn700 position move expects.
@@ -1143,15 +1586,10 @@ func (x *xArm) restoreGripperSpeed(ctx context.Context) error {
 // reachable at boot, gripperConn aliases cmdConn and behavior collapses to
 // the shared-socket case.
 func (x *xArm) gripperPreamble(write bool) cmd {
-	c := x.gripperConn.newCmd(regMap["GripperControl"])
-	c.params = append(c.params, 0x09) // host id
-	c.params = append(c.params, 0x08) // gripper id
 	if write {
-		c.params = append(c.params, 0x10)
-	} else {
-		c.params = append(c.params, 0x03)
+		return x.rs485Cmd(toolModbusHostID, gripperModbusID, modbusWriteMultiple)
 	}
-	return c
+	return x.rs485Cmd(toolModbusHostID, gripperModbusID, modbusReadHolding)
 }
 
 // gripperSend mirrors x.send but routes through gripperConn. checkError is
@@ -1180,25 +1618,16 @@ const (
 // The two cached values the arm keeps — gripperControlMode and gripperSpeed —
 // are updated here rather than by callers.
 func (x *xArm) writeGripperRegisters(ctx context.Context, addr uint16, values []uint16) error {
-	if len(values) == 0 || len(values) > 127 {
-		return fmt.Errorf("gripper register 0x%04X write: bad value count %d", addr, len(values))
-	}
-
-	c := x.gripperPreamble(true)
-	c.params = binary.BigEndian.AppendUint16(c.params, addr)
-	c.params = binary.BigEndian.AppendUint16(c.params, uint16(len(values))) //nolint:gosec // bounded by the caller.
-	c.params = append(c.params, byte(2*len(values)))
-	for _, v := range values {
-		c.params = binary.BigEndian.AppendUint16(c.params, v)
-	}
-
 	x.logger.Debugf("writeGripperRegisters 0x%04X <- %v", addr, values)
 
-	first := values[0]
+	var first uint16
+	if len(values) > 0 {
+		first = values[0]
+	}
 	if addr == gripperControlModeReg && first != 0 {
 		x.gripperControlMode.Store(true)
 	}
-	if _, err := x.gripperSend(ctx, c); err != nil {
+	if _, err := x.writeRS485Registers(ctx, toolModbusHostID, gripperModbusID, addr, values); err != nil {
 		return err
 	}
 	if addr == gripperControlModeReg && first == 0 {
@@ -1266,14 +1695,7 @@ type gripperRegRead struct {
 // readGripperRegisters reads count consecutive holding registers starting at
 // addr over the gripper bus.
 func (x *xArm) readGripperRegisters(ctx context.Context, addr, count uint16) (gripperRegRead, error) {
-	c := x.gripperPreamble(false)
-	c.params = binary.BigEndian.AppendUint16(c.params, addr)
-	c.params = binary.BigEndian.AppendUint16(c.params, count)
-	res, err := x.gripperSend(ctx, c)
-	if err != nil {
-		return gripperRegRead{}, err
-	}
-	return decodeGripperRegRead(addr, count, res.params)
+	return x.readRS485Registers(ctx, toolModbusHostID, gripperModbusID, modbusReadHolding, addr, count)
 }
 
 // decodeGripperRegRead splits a gripper-bus read response into an exception code
@@ -1362,6 +1784,23 @@ func (x *xArm) waitForGripper(ctx context.Context, goal int, stall time.Duration
 	}
 }
 
+// getGripperStatus reads the gripper status register, whose low two bits are one of
+// the gripperState values.
+func (x *xArm) getGripperStatus(ctx context.Context) (uint16, error) {
+	r, err := x.readGripperRegisters(ctx, standardGripperStatusReg, 1)
+	if err != nil {
+		return 0, err
+	}
+	if r.exception != 0 {
+		return 0, fmt.Errorf("gripper status read rejected with Modbus exception 0x%02X (%v)", r.exception, r.params)
+	}
+	w := r.words()
+	if len(w) != 1 {
+		return 0, fmt.Errorf("bad gripper status response %v", r.params)
+	}
+	return w[0], nil
+}
+
 func (x *xArm) getGripperPosition(ctx context.Context) (int32, error) {
 	r, err := x.readGripperRegisters(ctx, gripperCurrentPosReg, 2)
 	if err != nil {
@@ -1403,6 +1842,33 @@ func (x *xArm) getVacuumStatus(ctx context.Context, ct connectionType) (bool, er
 	return vacuumStateFromResponse(res.params, ct)
 }
 
+// toolDigitalInputsFromResponse decodes the DIGITAL_IN (0x0A14) word; params[3:5]
+// is the register value in big-endian, as for DIGITAL_OUT.
+func toolDigitalInputsFromResponse(params []byte) (uint16, error) {
+	if len(params) != 5 {
+		return 0, fmt.Errorf("DIGITAL_IN read returned %d bytes, want 5 (raw %v)", len(params), params)
+	}
+	return binary.BigEndian.Uint16(params[3:5]), nil
+}
+
+func (x *xArm) readToolDigitalInputs(ctx context.Context) (uint16, error) {
+	c := x.newCmd(regMap["VacuumState"])
+	c.params = append(c.params, 0x09, 0x0A, 0x14)
+	res, err := x.send(ctx, c, true)
+	if err != nil {
+		return 0, err
+	}
+	return toolDigitalInputsFromResponse(res.params)
+}
+
+// toolDigitalInputMask is the DIGITAL_IN bit for a tool input pin. The SDK's
+// get_tgpio_digital maps the input pins onto the word as tgpioCoreBits does the
+// output pins, which is how vacuumStateFromResponse finds pin 3 at bit 2.
+func toolDigitalInputMask(pin int) (uint16, bool) {
+	b, ok := tgpioCoreBits[pin+1]
+	return b.val, ok
+}
+
 // This is the host ID and gripper address which should be appended to each command.
 func (x *xArm) vacuumPreamble() cmd {
 	c := x.newCmd(regMap["VacuumControl"])
@@ -1535,13 +2001,103 @@ func (x *xArm) getLoad(ctx context.Context) ([]float64, error) {
 	if err != nil {
 		return []float64{}, err
 	}
-	var loads []float64
-	for i := 0; i < x.dof; i++ {
+	return parseJointFloats(loadData.params, x.dof)
+}
+
+// Servo registers read through ServoRead16 for joint telemetry.
+const (
+	servoRegTemperature = 0x000C // degrees Celsius
+	servoRegBusVoltage  = 0x000E // hundredths of a volt
+)
+
+// jointTelemetry is one sample of each joint's electrical and thermal state, indexed by joint.
+type jointTelemetry struct {
+	currents     []float64 // amps
+	torques      []float64 // Nm, estimated by the controller from the currents
+	temperatures []float64 // degrees Celsius
+	voltages     []float64 // volts, on each servo's bus
+}
+
+// parseJointFloats parses a response holding one little-endian float32 per joint after the state byte,
+// as returned by ActualCurrent and CurrentTorque.
+func parseJointFloats(params []byte, dof int) ([]float64, error) {
+	need := 1 + dof*4
+	if len(params) < need {
+		return nil, fmt.Errorf("unexpected joint data response length, got %d want >= %d", len(params), need)
+	}
+	vals := make([]float64, 0, dof)
+	for i := range dof {
 		idx := i*4 + 1
-		loads = append(loads, float64(rutils.Float32FromBytesLE((loadData.params[idx : idx+4]))))
+		vals = append(vals, float64(rutils.Float32FromBytesLE(params[idx:idx+4])))
+	}
+	return vals, nil
+}
+
+func (x *xArm) getJointCurrents(ctx context.Context) ([]float64, error) {
+	c := x.newCmd(regMap["ActualCurrent"])
+	resp, err := x.send(ctx, c, true)
+	if err != nil {
+		return nil, err
+	}
+	return parseJointFloats(resp.params, x.dof)
+}
+
+// readServoRegister reads a 16-bit register from the servo driving joint servoID (1-based). The
+// controller returns it sign-extended to a big-endian int32 after the state byte.
+func (x *xArm) readServoRegister(ctx context.Context, servoID int, addr uint16) (int32, error) {
+	c := x.newCmd(regMap["ServoRead16"])
+	c.params = append(c.params, byte(servoID))
+	c.params = binary.BigEndian.AppendUint16(c.params, addr)
+	resp, err := x.send(ctx, c, true)
+	if err != nil {
+		return 0, err
 	}
+	if len(resp.params) < 5 {
+		return 0, fmt.Errorf("unexpected servo %d register 0x%04x response length %d", servoID, addr, len(resp.params))
+	}
+	return int32(binary.BigEndian.Uint32(resp.params[1:5])), nil
+}
 
-	return loads, nil
+// getJointTelemetry reads the current, torque, temperature and bus voltage of every joint. Temperatures
+// and voltages are read from each servo in turn, so a sample costs two round trips per joint.
+func (x *xArm) getJointTelemetry(ctx context.Context) (jointTelemetry, error) {
+	var t jointTelemetry
+	var err error
+	if t.currents, err = x.getJointCurrents(ctx); err != nil {
+		return t, fmt.Errorf("reading joint currents: %w", err)
+	}
+	if t.torques, err = x.getLoad(ctx); err != nil {
+		return t, fmt.Errorf("reading joint torques: %w", err)
+	}
+	t.temperatures = make([]float64, x.dof)
+	t.voltages = make([]float64, x.dof)
+	for i := range x.dof {
+		temp, err := x.readServoRegister(ctx, i+1, servoRegTemperature)
+		if err != nil {
+			return t, fmt.Errorf("reading joint %d temperature: %w", i+1, err)
+		}
+		volts, err := x.readServoRegister(ctx, i+1, servoRegBusVoltage)
+		if err != nil {
+			return t, fmt.Errorf("reading joint %d bus voltage: %w", i+1, err)
+		}
+		t.temperatures[i] = float64(temp)
+		t.voltages[i] = float64(volts) / 100
+	}
+	return t, nil
+}
+
+// jointTelemetryReadingsMap flattens the telemetry into one key per joint and quantity, numbering joints
+// from 1 as the controller and UFACTORY Studio do, so each key is its own column in data capture.
+func jointTelemetryReadingsMap(t jointTelemetry) map[string]any {
+	out := map[string]any{}
+	for i := range t.currents {
+		prefix := fmt.Sprintf("joint_%d_", i+1)
+		out[prefix+"current_A"] = t.currents[i]
+		out[prefix+"torque_Nm"] = t.torques[i]
+		out[prefix+"temperature_C"] = t.temperatures[i]
+		out[prefix+"bus_voltage_V"] = t.voltages[i]
+	}
+	return out
 }
 
 // parseFTSensorData parses FTSensorData (0xC8): params[0] is a status byte, then six
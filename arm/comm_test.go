@@ -1,6 +1,7 @@
 package arm
 
 import (
+	"context"
 	"encoding/binary"
 	"math"
 	"testing"
@@ -8,6 +9,7 @@ import (
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/utils"
 	"go.viam.com/test"
@@ -32,6 +34,35 @@ func TestParseFTSensorData(t *testing.T) {
 	test.That(t, err, test.ShouldNotBeNil)
 }
 
+func TestParseJointFloats(t *testing.T) {
+	want := []float64{0.5, -1.25, 2, 0, 3.5, -0.75}
+	params := make([]byte, 1+7*4)
+	for i, v := range want {
+		binary.LittleEndian.PutUint32(params[i*4+1:i*4+5], math.Float32bits(float32(v)))
+	}
+
+	got, err := parseJointFloats(params, 6)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, got, test.ShouldResemble, want)
+
+	_, err = parseJointFloats(params[:10], 6)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestJointTelemetryReadingsMap(t *testing.T) {
+	m := jointTelemetryReadingsMap(jointTelemetry{
+		currents:     []float64{0.8, 2.3},
+		torques:      []float64{3.1, 9.4},
+		temperatures: []float64{41, 44},
+		voltages:     []float64{47.9, 48.1},
+	})
+	test.That(t, len(m), test.ShouldEqual, 8)
+	test.That(t, m["joint_1_current_A"], test.ShouldEqual, 0.8)
+	test.That(t, m["joint_2_torque_Nm"], test.ShouldEqual, 9.4)
+	test.That(t, m["joint_2_temperature_C"], test.ShouldEqual, 44.)
+	test.That(t, m["joint_1_bus_voltage_V"], test.ShouldEqual, 47.9)
+}
+
 func TestTrajectoryStreamValidator(t *testing.T) {
 	// point builds a trajectory point at time `d` with `dof` zeroed joint positions.
 	point := func(d time.Duration, dof int) arm.TrajectoryPoint {
@@ -179,3 +210,79 @@ func TestVacuumStateFromResponse(t *testing.T) {
 	_, err = vacuumStateFromResponse([]byte{0, 0}, connectionPlugin)
 	test.That(t, err, test.ShouldNotBeNil)
 }
+
+func TestToolDigitalInputsFromResponse(t *testing.T) {
+	inputs, err := toolDigitalInputsFromResponse([]byte{0, 0, 0, 0x01, 0x06})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inputs, test.ShouldEqual, uint16(0x0106))
+
+	_, err = toolDigitalInputsFromResponse([]byte{0, 0})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	mask, ok := toolDigitalInputMask(3)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, mask, test.ShouldEqual, uint16(0x04))
+	_, ok = toolDigitalInputMask(5)
+	test.That(t, ok, test.ShouldBeFalse)
+}
+
+func TestAppendJointParams(t *testing.T) {
+	x := &xArm{dof: 6}
+	mo := moveOptions{speed: 1.5, acceleration: 4}
+	params := x.appendJointParams(nil, []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6}, mo)
+
+	// Seven joints, with the seventh blank on a 6-DoF arm, then speed and acceleration.
+	test.That(t, len(params), test.ShouldEqual, 9*4)
+	f32 := func(i int) float64 {
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(params[i*4 : i*4+4])))
+	}
+	test.That(t, f32(0), test.ShouldAlmostEqual, 0.1, 1e-6)
+	test.That(t, f32(5), test.ShouldAlmostEqual, 0.6, 1e-6)
+	test.That(t, f32(6), test.ShouldEqual, 0)
+	test.That(t, f32(7), test.ShouldEqual, 1.5)
+	test.That(t, f32(8), test.ShouldEqual, 4)
+}
+
+func TestQueuesOnController(t *testing.T) {
+	x := &xArm{}
+	// Only direct moves are queued; a move that is neither direct nor interpolated is still streamed.
+	test.That(t, x.queuesOnController(moveOptions{direct: true}), test.ShouldBeTrue)
+	test.That(t, x.queuesOnController(moveOptions{direct: true, interpolate: true}), test.ShouldBeTrue)
+	test.That(t, x.queuesOnController(moveOptions{}), test.ShouldBeFalse)
+	test.That(t, x.queuesOnController(moveOptions{interpolate: true}), test.ShouldBeFalse)
+	test.That(t, x.plansFromStart(moveOptions{}), test.ShouldBeFalse)
+}
+
+func TestStreamedMoveChecksFirstPoint(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := MakeModelFrame("", ModelName6DOF, nil, nil, false, nil, logger, 0)
+	test.That(t, err, test.ShouldBeNil)
+	m, err = applyJointLimits(m, []JointLimitConfig{{Joint: 1, MinDegs: 10, MaxDegs: 90}})
+	test.That(t, err, test.ShouldBeNil)
+
+	c := &fakeArmController{}
+	c.joints[1] = float32(utils.DegToRad(45))
+	x := &xArm{
+		logger:  logger,
+		dof:     6,
+		model:   m,
+		moveHZ:  defaultMoveHz,
+		cmdConn: newModbusConn(startFakeArmController(t, c), logger, nil),
+		opMgr:   operation.NewSingleOperationManager(),
+	}
+	x.started.Store(-1)
+
+	// The arm is inside the limits, so a first point outside them can't widen them for the rest.
+	for _, first := range []float64{0, 45} {
+		batches := make(chan []arm.TrajectoryPoint, 1)
+		batches <- []arm.TrajectoryPoint{
+			{Positions: []referenceframe.Input{0, utils.DegToRad(first), 0, 0, 0, 0}},
+			{Time: time.Millisecond, Positions: []referenceframe.Input{0, 0, 0, 0, 0, 0}},
+		}
+		close(batches)
+		err = x.MoveThroughJointPositionsStreamed(context.Background(), batches, make(chan arm.Response, 1), nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "joint 1")
+	}
+	test.That(t, c.sent(regMap["MoveJoints"]), test.ShouldBeFalse)
+}
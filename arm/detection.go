@@ -74,6 +74,7 @@ type detectedArm struct {
 type detectedGripper struct {
 	kind     gripperKind
 	version  string
+	serial   string
 	submodel string
 }
 
@@ -208,12 +209,18 @@ func (x *xArm) detectVersion(ctx context.Context) (versionInfo, error) {
 	return parseVersionBanner(banner, x.logger)
 }
 
-// Modbus register start addresses used by gripper probes.
+// Modbus register start addresses, and the serial block length, used by gripper probes.
 const (
 	standardGripperVersionReg uint16 = 0x0801
 	bioGripperSNReg           uint16 = 0x0B10 // BIO serial-number block, up to 16 regs
+	gripperSNRegs                    = 16
 )
 
+// decodeGripperSerial turns a serial-number block into a string, dropping the padding.
+func decodeGripperSerial(data []byte) string {
+	return strings.TrimRight(string(data), "\x00 ")
+}
+
 func (x *xArm) detectStandardGripper(ctx context.Context) (detectedGripper, error) {
 	r, err := x.readGripperRegisters(ctx, standardGripperVersionReg, 3)
 	if err != nil {
@@ -244,7 +251,7 @@ func submodelFromForceControlProbe(r gripperRegRead) string {
 // detectBioGripper: byteCount==2 means v1 (single-register response), 2*numRegs
 // means v2 (full SN).
 func (x *xArm) detectBioGripper(ctx context.Context) (detectedGripper, error) {
-	const numRegs = 16
+	const numRegs = gripperSNRegs
 	c := x.gripperPreamble(false)
 	c.params = binary.BigEndian.AppendUint16(c.params, bioGripperSNReg)
 	c.params = binary.BigEndian.AppendUint16(c.params, numRegs)
@@ -266,8 +273,8 @@ func (x *xArm) detectBioGripper(ctx context.Context) (detectedGripper, error) {
 			return unknownGripper(),
 				fmt.Errorf("bio gripper response truncated: got %d, want %d", len(res.params), headerLen+int(byteCount))
 		}
-		sn := strings.TrimRight(string(res.params[headerLen:headerLen+int(byteCount)]), "\x00 ")
-		return detectedGripper{kind: gripperKindBio, version: "2 sn=" + sn}, nil
+		sn := decodeGripperSerial(res.params[headerLen : headerLen+int(byteCount)])
+		return detectedGripper{kind: gripperKindBio, version: "2", serial: sn}, nil
 	default:
 		return unknownGripper(),
 			fmt.Errorf("bio gripper unexpected byte count: %d (%v)", byteCount, res.params)
@@ -327,7 +334,7 @@ func probeGripper(ctx context.Context, a arm.Arm, kind gripperKind, logger loggi
 		logger.Warnf("%s gripper detection failed: %v", kind, err)
 		return d
 	}
-	logger.Infof("%s gripper detected (submodel=%q version=%q)", d.kind, d.submodel, d.version)
+	logger.Infof("%s gripper detected (submodel=%q version=%q serial=%q)", d.kind, d.submodel, d.version, d.serial)
 	return d
 }
 
@@ -37,6 +37,80 @@ var servoErrorMap = map[byte]string{
 	0x34: "xArm Servo: Initialization of Motor Angle Error",
 }
 
+// Controller error codes 0x0A-0x11 report that a servo motor has faulted: 0x0A for the servos in
+// general, and 0x0B-0x11 for joints 1-7. The servo's own error code says why.
+const (
+	errCodeServoMotor     = 0x0A
+	errCodeLastServoMotor = 0x11
+)
+
+// servoCount is the number of servos the ServoError register reports: seven joints and the gripper.
+const servoCount = 8
+
+func isServoMotorErrorCode(code byte) bool {
+	return code >= errCodeServoMotor && code <= errCodeLastServoMotor
+}
+
+// servoError is the error state of one servo as reported by the ServoError register. Servos are
+// numbered from 1 like joints; servo 8 drives the xArm gripper.
+type servoError struct {
+	servo  int
+	status byte
+	code   byte
+}
+
+func (e servoError) faulted() bool {
+	return e.status != 0
+}
+
+func (e servoError) description() string {
+	if !e.faulted() {
+		return ""
+	}
+	if msg, ok := servoErrorMap[e.code]; ok {
+		return msg
+	}
+	return fmt.Sprintf("xArm Servo: UNKNOWN ERROR (code=0x%02x)", e.code)
+}
+
+func (e servoError) Error() string {
+	return fmt.Sprintf("joint %d: %s (code 0x%02x)", e.servo, e.description(), e.code)
+}
+
+func (e servoError) toMap() map[string]any {
+	return map[string]any{
+		"joint":       float64(e.servo),
+		"faulted":     e.faulted(),
+		"code":        float64(e.code),
+		"description": e.description(),
+	}
+}
+
+// parseServoErrors parses a ServoError (0x6A) response: params[0] is the state byte, then a status
+// and an error code byte for each of the eight servos. A servo is only faulted when its status is
+// non-zero, since code 0x00 is itself a communication error.
+func parseServoErrors(params []byte) ([]servoError, error) {
+	if len(params) < 1+servoCount*2 {
+		return nil, errors.New("bad servo error query response")
+	}
+	errs := make([]servoError, servoCount)
+	for i := range servoCount {
+		errs[i] = servoError{servo: i + 1, status: params[i*2+1], code: params[i*2+2]}
+	}
+	return errs, nil
+}
+
+// servoFaultsErr combines the faulted servos into one error, or returns nil if none are faulted.
+func servoFaultsErr(errs []servoError) error {
+	var err error
+	for _, e := range errs {
+		if e.faulted() {
+			err = multierr.Append(err, e)
+		}
+	}
+	return err
+}
+
 // armBoxErrorMap maps controller error codes (decimal in UFactory docs, hex
 // on the wire) to human-readable descriptions.
 var armBoxErrorMap = map[byte]string{
@@ -92,19 +166,167 @@ var armBoxWarnMap = map[byte]string{
 	0x0F: "xArm Warning: Modbus cmd full",
 }
 
-func decodeError(params []byte) error {
-	errCode := params[1]
-	warnCode := params[2]
-	errMsg, isErr := armBoxErrorMap[errCode]
-	warnMsg, isWarn := armBoxWarnMap[warnCode]
-	if isErr || isWarn {
-		return multierr.Combine(errors.New(errMsg),
-			errors.New(warnMsg))
+// ControllerErrorKind classifies a controller error by its cause.
+type ControllerErrorKind string
+
+// Kinds of controller error.
+const (
+	// ErrorKindEStop is an emergency stop from the button, an emergency IO or the 3-state switch.
+	ErrorKindEStop ControllerErrorKind = "e_stop"
+	// ErrorKindCollision is a collision detected as abnormal joint current.
+	ErrorKindCollision ControllerErrorKind = "collision"
+	// ErrorKindServo is a fault in one of the joint servos.
+	ErrorKindServo ControllerErrorKind = "servo"
+	// ErrorKindJointLimit is a joint angle outside its limits.
+	ErrorKindJointLimit ControllerErrorKind = "joint_limit"
+	// ErrorKindSafetyBoundary is the tool leaving the configured safety boundary.
+	ErrorKindSafetyBoundary ControllerErrorKind = "safety_boundary"
+	// ErrorKindManualMode is an attempt to move the arm while it is in manual (teaching) mode.
+	ErrorKindManualMode ControllerErrorKind = "manual_mode"
+	// ErrorKindCommunication is a communication failure inside the arm or controller.
+	ErrorKindCommunication ControllerErrorKind = "communication"
+	// ErrorKindFTOverload is the F/T sensor latching an overload, which only a power cycle clears.
+	ErrorKindFTOverload ControllerErrorKind = "ft_overload"
+	// ErrorKindFTSensor is any other F/T sensor error.
+	ErrorKindFTSensor ControllerErrorKind = "ft_sensor"
+	// ErrorKindMotion is a command the controller could not plan or execute.
+	ErrorKindMotion ControllerErrorKind = "motion"
+	// ErrorKindWarning is a controller warning with no error.
+	ErrorKindWarning ControllerErrorKind = "warning"
+	// ErrorKindUnknown is an error code missing from the developer manual.
+	ErrorKindUnknown ControllerErrorKind = "unknown"
+)
+
+const errCodeManualMode = 0x25
+
+// controllerErrorKinds classifies the codes in armBoxErrorMap. Servo motor errors are classified by
+// isServoMotorErrorCode.
+var controllerErrorKinds = map[byte]ControllerErrorKind{
+	0x01:              ErrorKindEStop,
+	0x02:              ErrorKindEStop,
+	0x03:              ErrorKindEStop,
+	0x12:              ErrorKindCommunication,
+	0x13:              ErrorKindCommunication,
+	0x15:              ErrorKindMotion,
+	0x16:              ErrorKindMotion,
+	0x17:              ErrorKindJointLimit,
+	0x18:              ErrorKindMotion,
+	0x19:              ErrorKindMotion,
+	0x1A:              ErrorKindCommunication,
+	0x1B:              ErrorKindCommunication,
+	0x1C:              ErrorKindCommunication,
+	0x1D:              ErrorKindUnknown,
+	0x1E:              ErrorKindMotion,
+	errCodeCollision:  ErrorKindCollision,
+	0x20:              ErrorKindMotion,
+	0x21:              ErrorKindCommunication,
+	0x22:              ErrorKindMotion,
+	0x23:              ErrorKindSafetyBoundary,
+	0x24:              ErrorKindMotion,
+	errCodeManualMode: ErrorKindManualMode,
+	0x26:              ErrorKindJointLimit,
+	0x27:              ErrorKindCommunication,
+	0x28:              ErrorKindMotion,
+	0x32:              ErrorKindFTSensor,
+	0x33:              ErrorKindFTSensor,
+	0x34:              ErrorKindFTSensor,
+	0x35:              ErrorKindFTOverload,
+	0x3C:              ErrorKindMotion,
+	0x6E:              ErrorKindCommunication,
+	0x6F:              ErrorKindCommunication,
+}
+
+// ControllerError is an error or warning reported by the xArm controller. Callers can inspect it with
+// errors.As instead of matching the message.
+type ControllerError struct {
+	Kind ControllerErrorKind
+	// Code is the controller error code, 0 for a warning alone.
+	Code byte
+	// WarnCode is the controller warning code, 0 if there is no warning.
+	WarnCode byte
+	// Joint is the faulted joint, numbered from 1, or 0 if the error does not name one.
+	Joint int
+	// Recoverable is true when clearing the error lets the arm carry on, which the driver does
+	// automatically on the next command. When false an operator must act first, for example by
+	// releasing an e-stop or removing an obstacle.
+	Recoverable bool
+
+	state byte
+}
+
+func newControllerError(state, errCode, warnCode byte) *ControllerError {
+	e := &ControllerError{Code: errCode, WarnCode: warnCode, state: state}
+	switch {
+	case errCode == 0:
+		e.Kind = ErrorKindWarning
+	case isServoMotorErrorCode(errCode):
+		e.Kind = ErrorKindServo
+		e.Joint = int(errCode - errCodeServoMotor)
+	default:
+		var ok bool
+		if e.Kind, ok = controllerErrorKinds[errCode]; !ok {
+			e.Kind = ErrorKindUnknown
+		}
+	}
+	switch e.Kind {
+	case ErrorKindEStop, ErrorKindCollision, ErrorKindServo, ErrorKindManualMode, ErrorKindFTOverload, ErrorKindUnknown:
+		e.Recoverable = false
+	default:
+		e.Recoverable = true
+	}
+	return e
+}
+
+func (e *ControllerError) Error() string {
+	switch e.Kind {
+	case ErrorKindCollision:
+		// overcurrent estop has occurred, must be manually cleared by user.
+		return "collision caused overcurrent: ensure robot is clear of obstacles and clear error " +
+			"through UFACTORY Studio or clear_error do command"
+	case ErrorKindManualMode:
+		return "arm is in manual mode: use DoCommand with 'exit_manual_mode' to return to normal operation"
+	default:
+	}
+
+	errMsg, isErr := armBoxErrorMap[e.Code]
+	warnMsg, isWarn := armBoxWarnMap[e.WarnCode]
+	switch {
+	case isErr && isWarn:
+		return errMsg + "; " + warnMsg
+	case isErr:
+		return errMsg
+	case isWarn:
+		return warnMsg
+	default:
+		// Commands are returning error codes that are not mentioned in the
+		// developer manual — surface the raw bytes so users can cross-reference
+		// them in UFactory's docs / share with support.
+		return fmt.Sprintf("xArm: UNKNOWN ERROR (state=0x%02x errCode=0x%02x warnCode=0x%02x)", e.state, e.Code, e.WarnCode)
+	}
+}
+
+func (e *ControllerError) toMap() map[string]any {
+	return map[string]any{
+		"kind":        string(e.Kind),
+		"code":        float64(e.Code),
+		"warn_code":   float64(e.WarnCode),
+		"joint":       float64(e.Joint),
+		"recoverable": e.Recoverable,
+		"message":     e.Error(),
+	}
+}
+
+// controllerErrorMap returns the ControllerError in err's chain as a DoCommand response value, or nil
+// if there is none.
+func controllerErrorMap(err error) map[string]any {
+	var ce *ControllerError
+	if !errors.As(err, &ce) {
+		return nil
 	}
+	return ce.toMap()
+}
 
-	// Commands are returning error codes that are not mentioned in the
-	// developer manual — surface the raw bytes so users can cross-reference
-	// them in UFactory's docs / share with support.
-	return fmt.Errorf("xArm: UNKNOWN ERROR (state=0x%02x errCode=0x%02x warnCode=0x%02x)",
-		params[0], params[1], params[2])
+// decodeError converts the GetError response params into a *ControllerError.
+func decodeError(params []byte) error {
+	return newControllerError(params[0], params[1], params[2])
 }
@@ -0,0 +1,85 @@
+package arm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestParseServoErrors(t *testing.T) {
+	params := make([]byte, 1+servoCount*2)
+	// Joint 3 overheated; joint 5 lost communication, which is code 0x00 with a non-zero status.
+	params[3*2-1], params[3*2] = 1, 0x0F
+	params[5*2-1], params[5*2] = 1, 0x00
+
+	errs, err := parseServoErrors(params)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(errs), test.ShouldEqual, servoCount)
+	test.That(t, errs[0].faulted(), test.ShouldBeFalse)
+	test.That(t, errs[0].description(), test.ShouldEqual, "")
+	test.That(t, errs[2].faulted(), test.ShouldBeTrue)
+	test.That(t, errs[2].toMap()["joint"], test.ShouldEqual, 3.)
+	test.That(t, errs[2].toMap()["description"], test.ShouldEqual, servoErrorMap[0x0F])
+	test.That(t, errs[4].description(), test.ShouldEqual, servoErrorMap[0x00])
+
+	faults := servoFaultsErr(errs)
+	test.That(t, faults, test.ShouldNotBeNil)
+	test.That(t, faults.Error(), test.ShouldContainSubstring, "joint 3: xArm Servo: Joints Overheat")
+	test.That(t, faults.Error(), test.ShouldContainSubstring, "joint 5: xArm Servo: Joint Communication Error")
+
+	var se servoError
+	test.That(t, errors.As(faults, &se), test.ShouldBeTrue)
+	test.That(t, se.servo, test.ShouldEqual, 3)
+
+	test.That(t, servoFaultsErr(make([]servoError, servoCount)), test.ShouldBeNil)
+	_, err = parseServoErrors(params[:10])
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestIsServoMotorErrorCode(t *testing.T) {
+	test.That(t, isServoMotorErrorCode(0x09), test.ShouldBeFalse)
+	test.That(t, isServoMotorErrorCode(0x0A), test.ShouldBeTrue)
+	test.That(t, isServoMotorErrorCode(0x0D), test.ShouldBeTrue)
+	test.That(t, isServoMotorErrorCode(0x11), test.ShouldBeTrue)
+	test.That(t, isServoMotorErrorCode(errCodeCollision), test.ShouldBeFalse)
+}
+
+func TestDecodeError(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		params      []byte
+		kind        ControllerErrorKind
+		joint       int
+		recoverable bool
+		msg         string
+	}{
+		{"e-stop", []byte{0x40, 0x01, 0}, ErrorKindEStop, 0, false, "xArm: Emergency Stop Button Pushed In"},
+		{"collision", []byte{0x40, errCodeCollision, 0}, ErrorKindCollision, 0, false, "collision caused overcurrent"},
+		{"manual mode", []byte{0x40, errCodeManualMode, 0}, ErrorKindManualMode, 0, false, "exit_manual_mode"},
+		{"servo", []byte{0x40, 0x0D, 0}, ErrorKindServo, 3, false, "xArm: Servo motor 3 error"},
+		{"joint limit with warning", []byte{0x60, 0x17, 0x0C}, ErrorKindJointLimit, 0, true,
+			"xArm: Joint Angle Exceeds Limit; xArm Warning: Command Parameter Abnormal"},
+		{"ft overload", []byte{0x40, 0x35, 0}, ErrorKindFTOverload, 0, false, "overloaded"},
+		{"warning only", []byte{0x20, 0, 0x0F}, ErrorKindWarning, 0, true, "xArm Warning: Modbus cmd full"},
+		{"unknown", []byte{0x40, 0x99, 0}, ErrorKindUnknown, 0, false, "UNKNOWN ERROR (state=0x40 errCode=0x99"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := fmt.Errorf("moving: %w", decodeError(tc.params))
+			var ce *ControllerError
+			test.That(t, errors.As(err, &ce), test.ShouldBeTrue)
+			test.That(t, ce.Kind, test.ShouldEqual, tc.kind)
+			test.That(t, ce.Code, test.ShouldEqual, tc.params[1])
+			test.That(t, ce.WarnCode, test.ShouldEqual, tc.params[2])
+			test.That(t, ce.Joint, test.ShouldEqual, tc.joint)
+			test.That(t, ce.Recoverable, test.ShouldEqual, tc.recoverable)
+			test.That(t, err.Error(), test.ShouldContainSubstring, tc.msg)
+
+			m := controllerErrorMap(err)
+			test.That(t, m["kind"], test.ShouldEqual, string(tc.kind))
+			test.That(t, m["recoverable"], test.ShouldEqual, tc.recoverable)
+		})
+	}
+	test.That(t, controllerErrorMap(errors.New("connection reset")), test.ShouldBeNil)
+}
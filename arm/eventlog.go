@@ -0,0 +1,388 @@
+package arm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/utils"
+)
+
+// The event log is a bounded history of what happened to the arm: controller errors and warnings as
+// they are found, the clears that followed, motion mode changes, reconnects, and gripper and linear
+// track faults. Each event carries the joint positions at the time. Events are appended to a JSON
+// lines file in the module's data directory, so the history survives restarts, and are queried with
+// `get_event_log`.
+
+const (
+	getEventLogKey = "get_event_log"
+
+	// defaultEventLogSize is how many events are kept when event_log_size is not set.
+	defaultEventLogSize = 1000
+	// eventPositionTimeout bounds the joint position read attached to each event, so a wedged
+	// connection can't hold up the call that is recording it.
+	eventPositionTimeout = 500 * time.Millisecond
+
+	eventTypeError        = "error"
+	eventTypeWarning      = "warning"
+	eventTypeEStop        = "e_stop"
+	eventTypeClear        = "clear"
+	eventTypeModeChange   = "mode_change"
+	eventTypeReconnect    = "reconnect"
+	eventTypeGripperFault = "gripper_fault"
+	eventTypeTrackFault   = "linear_track_fault"
+	eventTypeGripperSlip  = "gripper_slip"
+)
+
+// armEvent is one entry in the event log. It is stored as JSON, so its fields are exported.
+type armEvent struct {
+	Seq     uint64    `json:"seq"`
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+	// Kind, Code, WarnCode and Joint are set from the ControllerError, if the event has one.
+	Kind               string    `json:"kind,omitempty"`
+	Code               int       `json:"code,omitempty"`
+	WarnCode           int       `json:"warn_code,omitempty"`
+	Joint              int       `json:"joint,omitempty"`
+	JointPositionsDegs []float64 `json:"joint_positions_degs,omitempty"`
+}
+
+func (e armEvent) toMap() map[string]any {
+	m := map[string]any{
+		"seq":     float64(e.Seq),
+		"time":    e.Time.Format(time.RFC3339Nano),
+		"type":    e.Type,
+		"message": e.Message,
+	}
+	if e.Kind != "" {
+		m["kind"] = e.Kind
+		m["code"] = float64(e.Code)
+		m["warn_code"] = float64(e.WarnCode)
+		m["joint"] = float64(e.Joint)
+	}
+	if e.JointPositionsDegs != nil {
+		positions := make([]any, len(e.JointPositionsDegs))
+		for i, p := range e.JointPositionsDegs {
+			positions[i] = p
+		}
+		m["joint_positions_degs"] = positions
+	}
+	return m
+}
+
+// eventLog is a ring buffer of events, mirrored to a file when path is set. A nil eventLog records
+// nothing.
+type eventLog struct {
+	mu       sync.Mutex
+	events   []armEvent // oldest first
+	capacity int
+	nextSeq  uint64
+	// activeFaults holds the faults recorded since the last clear, so a fault that fails every command
+	// until it is cleared is only recorded once.
+	activeFaults map[[2]int]bool
+
+	path      string
+	fileLines int // lines in the file, which is compacted once it holds twice the capacity
+	logger    logging.Logger
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// eventLogPath returns where the named arm's event log is kept, or "" if the module has no data
+// directory.
+func eventLogPath(armName string) string {
+	dir := os.Getenv("VIAM_MODULE_DATA")
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "event_log_"+unsafeFileChars.ReplaceAllString(armName, "_")+".jsonl")
+}
+
+// newEventLog creates an event log holding up to capacity events, and loads any events already in the
+// file at path.
+func newEventLog(path string, capacity int, logger logging.Logger) *eventLog {
+	l := &eventLog{capacity: capacity, path: path, logger: logger, activeFaults: map[[2]int]bool{}}
+	if path == "" {
+		return l
+	}
+	if err := l.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("could not load the event log from %s, starting a new one: %v", path, err)
+	}
+	return l
+}
+
+func (l *eventLog) load() error {
+	//nolint:gosec // the path is built from the module data directory and a sanitized arm name
+	f, err := os.Open(l.path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		l.fileLines++
+		var e armEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// A line cut short by a crash; skip it.
+			continue
+		}
+		l.appendLocked(e)
+		l.nextSeq = max(l.nextSeq, e.Seq)
+	}
+	return scanner.Err()
+}
+
+func (l *eventLog) appendLocked(e armEvent) {
+	l.events = append(l.events, e)
+	if len(l.events) > l.capacity {
+		l.events = l.events[len(l.events)-l.capacity:]
+	}
+}
+
+// add records e, numbering and timestamping it, and writes it to the file.
+func (l *eventLog) add(e armEvent) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nextSeq++
+	e.Seq = l.nextSeq
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	switch e.Type {
+	case eventTypeClear:
+		l.activeFaults = map[[2]int]bool{}
+	case eventTypeError, eventTypeWarning, eventTypeEStop:
+		l.activeFaults[faultKey(e.Code, e.WarnCode)] = true
+	default:
+	}
+	l.appendLocked(e)
+	if l.path != "" {
+		if err := l.persistLocked(e); err != nil {
+			l.logger.Warnf("could not write to the event log at %s: %v", l.path, err)
+		}
+	}
+}
+
+// faultActive reports whether a fault with these codes has been recorded since the last clear.
+func (l *eventLog) faultActive(code, warnCode byte) bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.activeFaults[faultKey(int(code), int(warnCode))]
+}
+
+// faultKey identifies a fault by its error code, or by its warning code if it is only a warning. A
+// command that fails reports both, while checkReadyState reports the error and warning separately.
+func faultKey(code, warnCode int) [2]int {
+	if code != 0 {
+		return [2]int{code, 0}
+	}
+	return [2]int{0, warnCode}
+}
+
+func (l *eventLog) persistLocked(e armEvent) error {
+	if l.fileLines >= 2*l.capacity {
+		return l.compactLocked()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	//nolint:gosec // see load
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	l.fileLines++
+	return f.Close()
+}
+
+// compactLocked rewrites the file with only the events in the buffer, replacing it atomically.
+func (l *eventLog) compactLocked() error {
+	tmp := l.path + ".tmp"
+	//nolint:gosec // see load
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, e := range l.events {
+		if err := enc.Encode(e); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return err
+	}
+	l.fileLines = len(l.events)
+	return nil
+}
+
+// eventFilter selects events for get_event_log.
+type eventFilter struct {
+	types map[string]bool // nil matches every type
+	since time.Time
+	until time.Time
+	limit int // the most recent limit events, 0 for all
+}
+
+func (f eventFilter) matches(e armEvent) bool {
+	if f.types != nil && !f.types[e.Type] {
+		return false
+	}
+	if !f.since.IsZero() && e.Time.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && e.Time.After(f.until) {
+		return false
+	}
+	return true
+}
+
+// parseEventFilter parses the get_event_log argument: `true` for every event, or a map with any of
+// `types`, `since` and `until` (RFC 3339 timestamps) and `limit`.
+func parseEventFilter(val any) (eventFilter, error) {
+	var f eventFilter
+	params, ok := val.(map[string]any)
+	if !ok {
+		return f, nil
+	}
+	if raw, ok := params["types"]; ok {
+		types, ok := raw.([]any)
+		if !ok {
+			return f, fmt.Errorf("%s.types must be a list of event types, got %T", getEventLogKey, raw)
+		}
+		f.types = map[string]bool{}
+		for _, t := range types {
+			s, ok := t.(string)
+			if !ok {
+				return f, fmt.Errorf("%s.types must be a list of strings, got %T", getEventLogKey, t)
+			}
+			f.types[s] = true
+		}
+	}
+	for key, dst := range map[string]*time.Time{"since": &f.since, "until": &f.until} {
+		raw, ok := params[key]
+		if !ok {
+			continue
+		}
+		s, ok := raw.(string)
+		if !ok {
+			return f, fmt.Errorf("%s.%s must be an RFC 3339 timestamp, got %T", getEventLogKey, key, raw)
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return f, fmt.Errorf("%s.%s: %w", getEventLogKey, key, err)
+		}
+		*dst = t
+	}
+	if raw, ok := params["limit"]; ok {
+		limit, ok := raw.(float64)
+		if !ok || limit < 0 {
+			return f, fmt.Errorf("%s.limit must be a non-negative number, got %v", getEventLogKey, raw)
+		}
+		f.limit = int(limit)
+	}
+	return f, nil
+}
+
+// query returns the events matching f, oldest first.
+func (l *eventLog) query(f eventFilter) []armEvent {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := []armEvent{}
+	for _, e := range l.events {
+		if f.matches(e) {
+			out = append(out, e)
+		}
+	}
+	if f.limit > 0 && len(out) > f.limit {
+		out = out[len(out)-f.limit:]
+	}
+	return out
+}
+
+// eventLogCommand handles the `get_event_log` DoCommand.
+func (x *xArm) eventLogCommand(val any) (map[string]any, error) {
+	f, err := parseEventFilter(val)
+	if err != nil {
+		return nil, err
+	}
+	events := x.events.query(f)
+	out := make([]any, len(events))
+	for i, e := range events {
+		out[i] = e.toMap()
+	}
+	return map[string]any{"events": out}, nil
+}
+
+// recordEvent adds an event to the log, with the arm's joint positions if they can be read.
+func (x *xArm) recordEvent(ctx context.Context, e armEvent) {
+	if x.events == nil {
+		return
+	}
+	if x.cmdConn != nil && !x.closed.Load() {
+		ctx, cancel := context.WithTimeout(ctx, eventPositionTimeout)
+		// Read without checking the state byte: the arm is often in an error state when an event is
+		// recorded, and checking it would record the event again.
+		if joints, err := x.readJointPositions(ctx, false); err == nil {
+			e.JointPositionsDegs = make([]float64, len(joints))
+			for i, j := range joints {
+				e.JointPositionsDegs[i] = utils.RadToDeg(j)
+			}
+		}
+		cancel()
+	}
+	x.events.add(e)
+}
+
+// recordControllerError records a controller error or warning, unless it has already been recorded
+// since the last clear.
+func (x *xArm) recordControllerError(ctx context.Context, err error) {
+	var ce *ControllerError
+	if !errors.As(err, &ce) || x.events.faultActive(ce.Code, ce.WarnCode) {
+		return
+	}
+	e := armEvent{
+		Type:     eventTypeError,
+		Message:  err.Error(),
+		Kind:     string(ce.Kind),
+		Code:     int(ce.Code),
+		WarnCode: int(ce.WarnCode),
+		Joint:    ce.Joint,
+	}
+	switch ce.Kind {
+	case ErrorKindWarning:
+		e.Type = eventTypeWarning
+	case ErrorKindEStop:
+		e.Type = eventTypeEStop
+	default:
+	}
+	x.recordEvent(ctx, e)
+}
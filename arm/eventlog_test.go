@@ -0,0 +1,101 @@
+package arm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/test"
+)
+
+func TestEventLogQuery(t *testing.T) {
+	l := newEventLog("", 3, logging.NewTestLogger(t))
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i, typ := range []string{eventTypeModeChange, eventTypeError, eventTypeClear, eventTypeReconnect} {
+		l.add(armEvent{Time: start.Add(time.Duration(i) * time.Minute), Type: typ})
+	}
+
+	// Only the three most recent events are kept, numbered in order.
+	all := l.query(eventFilter{})
+	test.That(t, len(all), test.ShouldEqual, 3)
+	test.That(t, all[0].Seq, test.ShouldEqual, uint64(2))
+	test.That(t, all[2].Type, test.ShouldEqual, eventTypeReconnect)
+
+	f, err := parseEventFilter(map[string]any{"types": []any{eventTypeError, eventTypeReconnect}, "limit": 1.})
+	test.That(t, err, test.ShouldBeNil)
+	got := l.query(f)
+	test.That(t, len(got), test.ShouldEqual, 1)
+	test.That(t, got[0].Type, test.ShouldEqual, eventTypeReconnect)
+
+	f, err = parseEventFilter(map[string]any{
+		"since": start.Add(time.Minute).Format(time.RFC3339),
+		"until": start.Add(2 * time.Minute).Format(time.RFC3339),
+	})
+	test.That(t, err, test.ShouldBeNil)
+	got = l.query(f)
+	test.That(t, len(got), test.ShouldEqual, 2)
+	test.That(t, got[1].Type, test.ShouldEqual, eventTypeClear)
+
+	for _, bad := range []map[string]any{
+		{"types": eventTypeError},
+		{"since": "yesterday"},
+		{"limit": -1.},
+	} {
+		_, err := parseEventFilter(bad)
+		test.That(t, err, test.ShouldNotBeNil)
+	}
+	f, err = parseEventFilter(true)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(l.query(f)), test.ShouldEqual, 3)
+}
+
+func TestEventLogActiveFaults(t *testing.T) {
+	l := newEventLog("", 10, logging.NewTestLogger(t))
+	test.That(t, l.faultActive(0x17, 0), test.ShouldBeFalse)
+
+	// A failed command reports the error and warning together; it is the same fault as the error alone.
+	l.add(armEvent{Type: eventTypeError, Code: 0x17, WarnCode: 0x0C})
+	test.That(t, l.faultActive(0x17, 0), test.ShouldBeTrue)
+	test.That(t, l.faultActive(0, 0x0C), test.ShouldBeFalse)
+
+	l.add(armEvent{Type: eventTypeClear, Code: 0x17})
+	test.That(t, l.faultActive(0x17, 0), test.ShouldBeFalse)
+
+	var nilLog *eventLog
+	nilLog.add(armEvent{Type: eventTypeError})
+	test.That(t, nilLog.faultActive(0x17, 0), test.ShouldBeFalse)
+	test.That(t, nilLog.query(eventFilter{}), test.ShouldBeNil)
+}
+
+func TestEventLogPersistence(t *testing.T) {
+	t.Setenv("VIAM_MODULE_DATA", t.TempDir())
+	path := eventLogPath("my arm/1")
+	test.That(t, filepath.Base(path), test.ShouldEqual, "event_log_my_arm_1.jsonl")
+
+	logger := logging.NewTestLogger(t)
+	l := newEventLog(path, 2, logger)
+	for i := range 5 {
+		l.add(armEvent{Type: eventTypeModeChange, JointPositionsDegs: []float64{float64(i), 0}})
+	}
+
+	// The file is compacted once it holds twice the capacity.
+	data, err := os.ReadFile(path)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, strings.Count(string(data), "\n"), test.ShouldBeLessThanOrEqualTo, 4)
+
+	// A restart picks up the most recent events and carries on numbering them.
+	reloaded := newEventLog(path, 2, logger)
+	got := reloaded.query(eventFilter{})
+	test.That(t, len(got), test.ShouldEqual, 2)
+	test.That(t, got[1].Seq, test.ShouldEqual, uint64(5))
+	test.That(t, got[1].JointPositionsDegs, test.ShouldResemble, []float64{4, 0})
+	reloaded.add(armEvent{Type: eventTypeReconnect})
+	test.That(t, reloaded.query(eventFilter{limit: 1})[0].Seq, test.ShouldEqual, uint64(6))
+
+	test.That(t, eventLogPath("arm"), test.ShouldNotEqual, "")
+	t.Setenv("VIAM_MODULE_DATA", "")
+	test.That(t, eventLogPath("arm"), test.ShouldEqual, "")
+}
@@ -3,6 +3,7 @@ package arm
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"go.viam.com/rdk/components/arm"
@@ -18,6 +19,7 @@ import (
 type fakeArm struct {
 	arm.Arm
 
+	mu      sync.Mutex
 	lastCmd map[string]any
 	resp    map[string]any
 	err     error
@@ -25,7 +27,9 @@ type fakeArm struct {
 }
 
 func (f *fakeArm) DoCommand(_ context.Context, cmd map[string]any) (map[string]any, error) {
+	f.mu.Lock()
 	f.lastCmd = cmd
+	f.mu.Unlock()
 	if f.doFn != nil {
 		return f.doFn(cmd)
 	}
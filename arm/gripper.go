@@ -2,6 +2,7 @@ package arm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -33,10 +34,11 @@ var (
 	GripperModelLite = family.WithModel(ModelNameGripperLite)
 )
 
-// Raw Fn700/Fn702 pulse thresholds. The register scale (0..850 over the full
-// stroke) is the same on G1 and G2 — only the millimetre mapping differs, and we
-// never expose millimetres — so these serve both. On G2 they are a fallback:
+// Raw Fn700/Fn702 pulse thresholds. The register scale (0..gripperMaxPulses over
+// the full stroke) is the same on G1 and G2 — only the millimetre mapping differs,
+// see standardGripperDimensions — so these serve both. On G2 they are a fallback:
 // holding is read from the status register instead.
+const gripperMaxPulses = 850
 const fullyClosedThreshold = 10
 const fullyOpenThreshold = 830
 const fullyOpenPosition = 840
@@ -53,6 +55,47 @@ const (
 // gripperStatusTimeout bounds the G2 move poll, matching the SDKs' 10s default.
 const gripperStatusTimeout = 10 * time.Second
 
+// g1GrabPosition is where a G1 Grab aims by default. The G2 closes to 0, matching the
+// SDK's clamp; the G1 keeps its historical target of 2.
+const g1GrabPosition = 2
+
+// Physical-unit keys: the Grab/Open extras, the DoCommands, and the fields that
+// report millimetres next to the raw values. See gripper_units.go.
+const (
+	gripperWidthMMKey          = "width_mm"
+	gripperSpeedExtraKey       = "speed_mm_per_sec"
+	setGripperWidthMMKey       = "set_width_mm"
+	setGripperSpeedMMPerSecKey = "set_gripper_speed_mm_per_sec"
+	gripperSpeedMMPerSecKey    = "gripper_speed_mm_per_sec"
+)
+
+// The Lite gripper has no position feedback, so its moves are timed: the jaws get
+// move_time_sec to travel and settle_time_sec to come to rest before holding is read.
+const (
+	defaultLiteGripperMoveTime   = time.Second
+	defaultLiteGripperSettleTime = 200 * time.Millisecond
+)
+
+// Sources the Lite gripper reads holding from, reported in the
+// IsHoldingSomething metadata.
+const (
+	liteHoldingSourceBio       = "bio_status"
+	liteHoldingSourceInputs    = "tool_inputs"
+	liteHoldingSourceCommanded = "commanded"
+)
+
+// errLiteGripperStopped ends a Lite gripper move that Stop cut short.
+var errLiteGripperStopped = errors.New("gripper lite move was stopped")
+
+// Per-move keys in raw units, taken by Grab/Open extras and by the grasp
+// DoCommand, which also takes the physical-unit ones.
+const (
+	gripperPositionExtraKey = "position"
+	gripperSpeedRawKey      = "speed"
+	gripperForceKey         = "force"
+	graspKey                = "grasp"
+)
+
 // GripperConfig config for gripper.
 type GripperConfig struct {
 	Arm            string
@@ -78,6 +121,20 @@ type GripperConfig struct {
 	// call site substitutes an internal default when nil. Must be in (0, 1]
 	// when set — 0 is a validation error (not a "use the default" sentinel).
 	MeshDecimationRatio *float64 `json:"mesh_decimation_ratio,omitempty"`
+	// SlipMonitor watches a held object for slips and drops. Standard gripper only; nil leaves
+	// it off.
+	SlipMonitor *SlipMonitorConfig `json:"slip_monitor,omitempty"`
+	// HoldingInputs are the tool digital input pins that read active while the
+	// Lite gripper holds something, any one of them being enough. Lite only, and
+	// unused when a BIO gripper is detected, whose status says so itself.
+	HoldingInputs []int `json:"holding_inputs,omitempty"`
+	// HoldingInputsActiveLow reads the holding inputs as active when low.
+	HoldingInputsActiveLow bool `json:"holding_inputs_active_low,omitempty"`
+	// MoveTimeSec and SettleTimeSec time the Lite gripper's moves: how long the
+	// jaws take to travel, then how long they take to come to rest. 0 means
+	// defaultLiteGripperMoveTime and defaultLiteGripperSettleTime.
+	MoveTimeSec   float64 `json:"move_time_sec,omitempty"`
+	SettleTimeSec float64 `json:"settle_time_sec,omitempty"`
 }
 
 // Validate validates the config.
@@ -107,6 +164,22 @@ func (cfg *GripperConfig) Validate(path string) ([]string, []string, error) {
 			return nil, nil, fmt.Errorf("mesh_decimation_ratio must be in (0, 1] when set, got %f", r)
 		}
 	}
+	if cfg.SlipMonitor != nil {
+		if err := cfg.SlipMonitor.validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, pin := range cfg.HoldingInputs {
+		if _, ok := toolDigitalInputMask(pin); !ok {
+			return nil, nil, fmt.Errorf("holding_inputs: tool digital input %d does not exist, must be between 0 and %d", pin, len(tgpioCoreBits)-1)
+		}
+	}
+	if cfg.MoveTimeSec < 0 {
+		return nil, nil, fmt.Errorf("move_time_sec must not be negative, got %v", cfg.MoveTimeSec)
+	}
+	if cfg.SettleTimeSec < 0 {
+		return nil, nil, fmt.Errorf("settle_time_sec must not be negative, got %v", cfg.SettleTimeSec)
+	}
 	return []string{cfg.Arm}, nil, nil
 }
 
@@ -135,6 +208,16 @@ type myGripperLite struct {
 	arm      arm.Arm
 	isMoving atomic.Bool
 
+	// moveLock serializes moves; cancelMove, guarded by cancelLock, lets Stop cut
+	// the one in progress short.
+	moveLock   sync.Mutex
+	cancelLock sync.Mutex
+	cancelMove context.CancelCauseFunc
+
+	moveTime, settleTime time.Duration
+	holdingInputs        []int
+	activeLow            bool
+
 	detected detectedGripper
 
 	logger logging.Logger
@@ -152,11 +235,21 @@ func newGripperLite(ctx context.Context, deps resource.Dependencies, config reso
 	}
 
 	g := &myGripperLite{
-		name:     config.ResourceName(),
-		mf:       mf,
-		useURDFs: newConf.UseURDFs,
-		logger:   logger,
-		isMoving: atomic.Bool{},
+		name:          config.ResourceName(),
+		mf:            mf,
+		useURDFs:      newConf.UseURDFs,
+		moveTime:      defaultLiteGripperMoveTime,
+		settleTime:    defaultLiteGripperSettleTime,
+		holdingInputs: newConf.HoldingInputs,
+		activeLow:     newConf.HoldingInputsActiveLow,
+		logger:        logger,
+		isMoving:      atomic.Bool{},
+	}
+	if newConf.MoveTimeSec != 0 {
+		g.moveTime = time.Duration(newConf.MoveTimeSec * float64(time.Second))
+	}
+	if newConf.SettleTimeSec != 0 {
+		g.settleTime = time.Duration(newConf.SettleTimeSec * float64(time.Second))
 	}
 
 	g.arm, err = arm.FromProvider(deps, newConf.Arm)
@@ -165,59 +258,173 @@ func newGripperLite(ctx context.Context, deps resource.Dependencies, config reso
 	}
 
 	g.detected = probeGripper(ctx, g.arm, gripperKindBio, logger)
+	if g.detected.kind == gripperKindBio && len(g.holdingInputs) > 0 {
+		logger.Info("gripper_lite: a BIO gripper reports holding itself, so holding_inputs are ignored")
+	}
 
 	return g, nil
 }
 
+func (g *myGripperLite) bus() (*xArm, error) {
+	return rutils.AssertType[*xArm](g.arm)
+}
+
+// Grab closes the jaws and reports whether they closed on something; see
+// IsHoldingSomething.
 func (g *myGripperLite) Grab(ctx context.Context, extra map[string]any) (bool, error) {
-	g.isMoving.Store(true)
-	defer g.isMoving.Store(false)
-	if _, err := g.arm.DoCommand(ctx, map[string]any{
-		gripperLiteActionKey: gripperLiteActionClose,
-	}); err != nil {
+	if err := g.move(ctx, gripperLiteActionClose); err != nil {
 		return false, err
 	}
-	return true, nil
+	status, err := g.IsHoldingSomething(ctx, extra)
+	if err != nil {
+		return false, err
+	}
+	return status.IsHoldingSomething, nil
 }
 
 func (g *myGripperLite) Open(ctx context.Context, extra map[string]any) error {
+	return g.move(ctx, gripperLiteActionOpen)
+}
+
+// move drives the jaws with action and waits for them to stop: on the BIO
+// gripper's status register when one is detected, otherwise for the configured
+// move and settle time. IsMoving is true until then, unless Stop ends it sooner.
+func (g *myGripperLite) move(ctx context.Context, action string) error {
+	g.moveLock.Lock()
+	defer g.moveLock.Unlock()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	g.cancelLock.Lock()
+	g.cancelMove = cancel
+	g.cancelLock.Unlock()
+	defer func() {
+		g.cancelLock.Lock()
+		g.cancelMove = nil
+		g.cancelLock.Unlock()
+	}()
+
 	g.isMoving.Store(true)
 	defer g.isMoving.Store(false)
-	_, err := g.arm.DoCommand(ctx, map[string]any{
-		gripperLiteActionKey: gripperLiteActionOpen,
-	})
-	return err
+
+	// A BIO gripper is a Modbus device on the tool bus rather than a pair of TGPIO outputs, so it
+	// gets its own move command, as the bio_gripper model sends it.
+	if g.detected.kind == gripperKindBio {
+		x, err := g.bus()
+		if err != nil {
+			return err
+		}
+		goal := bioGripperOpenPosition
+		if action == gripperLiteActionClose {
+			goal = bioGripperClosePosition
+		}
+		submodel := bioGripperSubmodel(g.detected.version)
+		setup := func(ctx context.Context) error {
+			return setupBioGripper(ctx, x, submodel, defaultBioGripperSpeed, defaultBioGripperForce)
+		}
+		if _, err := moveBioGripper(ctx, x, g.name, goal, setup); err != nil {
+			if cause := context.Cause(ctx); cause != nil {
+				return cause
+			}
+			return err
+		}
+		return nil
+	}
+	if _, err := g.arm.DoCommand(ctx, map[string]any{gripperLiteActionKey: action}); err != nil {
+		return err
+	}
+	if !utils.SelectContextOrWait(ctx, g.moveTime+g.settleTime) {
+		return context.Cause(ctx)
+	}
+	return nil
 }
 
-// IsHoldingSomething reads DIGITAL_OUT (0x0A15) via TGPIO_R16B — see the
-// gripperLiteActionIsClosed case in liteGripperAction.
+// IsHoldingSomething reads the BIO gripper's object-detected bit when one was
+// detected, else the holding inputs when they are configured. Without either
+// it can only report whether the jaws were commanded closed.
 func (g *myGripperLite) IsHoldingSomething(
 	ctx context.Context,
 	extra map[string]any,
 ) (gripper.HoldingStatus, error) {
+	switch {
+	case g.detected.kind == gripperKindBio:
+		x, err := g.bus()
+		if err != nil {
+			return gripper.HoldingStatus{}, err
+		}
+		status, err := x.getGripperStatus(ctx)
+		if err != nil {
+			return gripper.HoldingStatus{}, err
+		}
+		return gripper.HoldingStatus{
+			IsHoldingSomething: status&gripperStateMask == gripperStateDetected,
+			Meta:               map[string]any{"source": liteHoldingSourceBio, "status": status},
+		}, nil
+	case len(g.holdingInputs) > 0:
+		x, err := g.bus()
+		if err != nil {
+			return gripper.HoldingStatus{}, err
+		}
+		inputs, err := x.readToolDigitalInputs(ctx)
+		if err != nil {
+			return gripper.HoldingStatus{}, err
+		}
+		return gripper.HoldingStatus{
+			IsHoldingSomething: liteHoldingFromInputs(inputs, g.holdingInputs, g.activeLow),
+			Meta:               map[string]any{"source": liteHoldingSourceInputs, "inputs": inputs},
+		}, nil
+	}
+	closed, err := g.commandedClosed(ctx)
+	if err != nil {
+		return gripper.HoldingStatus{}, err
+	}
+	return gripper.HoldingStatus{
+		IsHoldingSomething: closed,
+		Meta:               map[string]any{"source": liteHoldingSourceCommanded},
+	}, nil
+}
+
+// liteHoldingFromInputs reports whether any of pins is active in the DIGITAL_IN
+// word inputs.
+func liteHoldingFromInputs(inputs uint16, pins []int, activeLow bool) bool {
+	for _, pin := range pins {
+		mask, ok := toolDigitalInputMask(pin)
+		if !ok {
+			continue
+		}
+		if (inputs&mask != 0) != activeLow {
+			return true
+		}
+	}
+	return false
+}
+
+// commandedClosed reads DIGITAL_OUT (0x0A15) via TGPIO_R16B — see the
+// gripperLiteActionIsClosed case in liteGripperAction.
+func (g *myGripperLite) commandedClosed(ctx context.Context) (bool, error) {
 	res, err := g.arm.DoCommand(ctx, map[string]any{
 		gripperLiteActionKey: gripperLiteActionIsClosed,
 	})
 	if err != nil {
-		return gripper.HoldingStatus{}, err
+		return false, err
 	}
 	val, ok := res[gripperLiteActionKey]
 	if !ok {
-		return gripper.HoldingStatus{}, fmt.Errorf("command %s didn't return key %s instead got %+v", gripperLiteActionIsClosed, gripperLiteActionKey, res)
+		return false, fmt.Errorf("command %s didn't return key %s instead got %+v", gripperLiteActionIsClosed, gripperLiteActionKey, res)
 	}
 	converted, ok := val.(map[string]any)
 	if !ok {
-		return gripper.HoldingStatus{}, fmt.Errorf("expected map[string]interface{} got %v of type %T", val, val)
+		return false, fmt.Errorf("expected map[string]interface{} got %v of type %T", val, val)
 	}
 	isHoldingRaw, ok := converted[gripperLiteActionIsClosed]
 	if !ok {
-		return gripper.HoldingStatus{}, fmt.Errorf("response doesn't contain the key: %s have : %v", gripperLiteActionIsClosed, val)
+		return false, fmt.Errorf("response doesn't contain the key: %s have : %v", gripperLiteActionIsClosed, val)
 	}
 	isHolding, ok := isHoldingRaw.(bool)
 	if !ok {
-		return gripper.HoldingStatus{}, fmt.Errorf("key `%s` value is not a bool, %v is a %T", gripperLiteActionIsClosed, isHoldingRaw, isHoldingRaw)
+		return false, fmt.Errorf("key `%s` value is not a bool, %v is a %T", gripperLiteActionIsClosed, isHoldingRaw, isHoldingRaw)
 	}
-	return gripper.HoldingStatus{IsHoldingSomething: isHolding}, nil
+	return isHolding, nil
 }
 
 func (g *myGripperLite) Name() resource.Name {
@@ -236,8 +443,24 @@ func (g *myGripperLite) IsMoving(context.Context) (bool, error) {
 	return g.isMoving.Load(), nil
 }
 
+// Stop ends the move in progress and releases the jaws. A BIO gripper is held where it is instead.
 func (g *myGripperLite) Stop(ctx context.Context, extra map[string]any) error {
-	defer g.isMoving.Store(false)
+	moving := g.isMoving.Load()
+	g.cancelLock.Lock()
+	if g.cancelMove != nil {
+		g.cancelMove(errLiteGripperStopped)
+	}
+	g.cancelLock.Unlock()
+	if g.detected.kind == gripperKindBio {
+		if !moving {
+			return nil
+		}
+		x, err := g.bus()
+		if err != nil {
+			return err
+		}
+		return holdBioGripper(ctx, x)
+	}
 	_, err := g.arm.DoCommand(ctx, map[string]any{
 		gripperLiteActionKey: gripperLiteActionStop,
 	})
@@ -274,9 +497,10 @@ func (g *myGripperLite) Status(_ context.Context) (map[string]any, error) {
 type myGripper struct {
 	resource.AlwaysRebuild
 
-	name     resource.Name
-	mf       referenceframe.Model
-	useURDFs bool
+	name resource.Name
+	// mf has the jaw width as its one joint, unless use_urdfs swapped in the
+	// zero-DoF mesh model.
+	mf referenceframe.Model
 
 	arm arm.Arm
 
@@ -287,6 +511,12 @@ type myGripper struct {
 	// speed and force are the resolved G2 FnCxx parameters. Unused on G1.
 	speed, force uint16
 
+	// holdMu guards the last grasp report and the object the slip monitor is watching.
+	holdMu    sync.Mutex
+	lastGrasp *graspReport
+	hold      gripperHold
+	workers   *utils.StoppableWorkers
+
 	logger logging.Logger
 }
 
@@ -321,9 +551,12 @@ func newGripper(ctx context.Context, deps resource.Dependencies, config resource
 		logger.Warn("gripper: use_urdfs is set but only the G1 mesh ships, so this G2 will report G1 collision geometry; " +
 			"unset use_urdfs to get the G2 bounding boxes")
 	}
-	mf, err := newGripperKinematics(ModelNameGripper, newConf, logger, func() ([]spatialmath.Geometry, error) {
-		return standardGripperGeometries(submodel)
-	})
+	var mf referenceframe.Model
+	if newConf.UseURDFs {
+		mf, err = loadGripperModel(ModelNameGripper, newConf.MeshDecimationRatio, logger)
+	} else {
+		mf, err = standardGripperModel(submodel)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("gripper kinematics: %w", err)
 	}
@@ -331,7 +564,6 @@ func newGripper(ctx context.Context, deps resource.Dependencies, config resource
 	g := &myGripper{
 		name:     config.ResourceName(),
 		mf:       mf,
-		useURDFs: newConf.UseURDFs,
 		arm:      a,
 		detected: detected,
 		force:    defaultGripperForceG2,
@@ -358,6 +590,9 @@ func newGripper(ctx context.Context, deps resource.Dependencies, config resource
 		}
 	}
 
+	if slip := newConf.SlipMonitor; slip != nil {
+		g.workers = utils.NewBackgroundStoppableWorkers(func(ctx context.Context) { g.monitorSlip(ctx, slip) })
+	}
 	return g, nil
 }
 
@@ -374,31 +609,149 @@ func (g *myGripper) submodel() string {
 	return g.detected.submodel
 }
 
-func (g *myGripper) Grab(ctx context.Context, extra map[string]any) (bool, error) {
-	// G2 closes to 0, matching the SDK's clamp; G1 keeps its historical target of 2.
-	if g.submodel() == submodelG2 {
-		status, err := g.moveG2(ctx, 0)
-		if err != nil {
-			return false, err
+// gripperMove is one jaw move: a raw target position and, when non-zero, a raw
+// speed and a G2 force for this move only.
+type gripperMove struct {
+	goal  int
+	speed uint16
+	force uint16
+}
+
+// gripperMoveNumber reads a numeric move key. Keys that are absent report false.
+func gripperMoveNumber(params map[string]any, key string) (float64, bool, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, false, nil
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return 0, false, fmt.Errorf("%s must be a number, got %T", key, v)
+	}
+	return f, true, nil
+}
+
+// moveFromParams builds a move to goal, overridden by the move keys in params:
+// the target as position or width_mm, the speed as speed or speed_mm_per_sec,
+// and on a G2 the force. Unset speed and force fall back to the configured ones.
+func (g *myGripper) moveFromParams(params map[string]any, goal int) (gripperMove, error) {
+	m := gripperMove{goal: goal}
+
+	pos, hasPos, err := gripperMoveNumber(params, gripperPositionExtraKey)
+	if err != nil {
+		return m, err
+	}
+	mm, hasMM, err := gripperMoveNumber(params, gripperWidthMMKey)
+	if err != nil {
+		return m, err
+	}
+	switch {
+	case hasPos && hasMM:
+		return m, fmt.Errorf("set only one of %s and %s", gripperPositionExtraKey, gripperWidthMMKey)
+	case hasPos:
+		if pos < 0 || pos > gripperMaxPulses {
+			return m, fmt.Errorf("%s must be between 0 and %d, got %v", gripperPositionExtraKey, gripperMaxPulses, pos)
+		}
+		m.goal = int(pos)
+	case hasMM:
+		if m.goal, err = gripperWidthPulses(g.submodel(), mm); err != nil {
+			return m, err
+		}
+	}
+
+	speed, hasSpeed, err := gripperMoveNumber(params, gripperSpeedRawKey)
+	if err != nil {
+		return m, err
+	}
+	mmPerSec, hasMMPerSec, err := gripperMoveNumber(params, gripperSpeedExtraKey)
+	if err != nil {
+		return m, err
+	}
+	switch {
+	case hasSpeed && hasMMPerSec:
+		return m, fmt.Errorf("set only one of %s and %s", gripperSpeedRawKey, gripperSpeedExtraKey)
+	case hasSpeed:
+		if speed < 1 || speed > maxGripperSpeed {
+			return m, fmt.Errorf("%s must be between 1 and %d, got %v", gripperSpeedRawKey, maxGripperSpeed, speed)
+		}
+		m.speed = uint16(speed)
+	case hasMMPerSec:
+		if m.speed, err = gripperSpeedRaw(g.submodel(), mmPerSec); err != nil {
+			return m, err
 		}
-		return status&gripperStateMask == gripperStateDetected, nil
 	}
 
-	pos, err := g.goToPosition(ctx, 2)
+	force, hasForce, err := gripperMoveNumber(params, gripperForceKey)
+	if err != nil {
+		return m, err
+	}
+	if hasForce {
+		if g.submodel() != submodelG2 {
+			return m, fmt.Errorf("%s needs a G2 gripper; a G1 has no force control", gripperForceKey)
+		}
+		if force < 1 || force > 100 {
+			return m, fmt.Errorf("%s must be between 1 and 100, got %v", gripperForceKey, force)
+		}
+		m.force = uint16(force)
+	}
+	return m, nil
+}
+
+// Grab closes the jaws, fully unless extra sets a target; see moveFromParams. How
+// the grasp went is kept as the grasp report.
+func (g *myGripper) Grab(ctx context.Context, extra map[string]any) (bool, error) {
+	goal := g1GrabPosition
+	if g.submodel() == submodelG2 {
+		goal = 0
+	}
+	m, err := g.moveFromParams(extra, goal)
 	if err != nil {
 		return false, err
 	}
+	r, err := g.close(ctx, m)
+	if err != nil {
+		return false, err
+	}
+	return r.holding, nil
+}
 
-	return pos > fullyClosedThreshold, nil
+// close makes a closing move, records its grasp report and, if it ended on an
+// object, hands that to the slip monitor.
+func (g *myGripper) close(ctx context.Context, m gripperMove) (*graspReport, error) {
+	start := time.Now()
+	r := &graspReport{}
+	if g.submodel() == submodelG2 {
+		status, err := g.moveG2(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		r.timeToClose = time.Since(start)
+		r.holding = status&gripperStateMask == gripperStateDetected
+		r.status = &status
+		if r.position, err = g.getPosition(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		pos, err := g.goToPosition(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		r.timeToClose = time.Since(start)
+		// Holding means the jaws stopped short of where they were sent.
+		r.holding = pos > m.goal+fullyClosedThreshold-g1GrabPosition
+		r.position = pos
+	}
+	r.widthMM = gripperWidthMM(g.submodel(), r.position)
+	g.recordGrasp(r)
+	return r, nil
 }
 
+// Open opens the jaws, fully unless extra sets a target; see moveFromParams.
 func (g *myGripper) Open(ctx context.Context, extra map[string]any) error {
-	if g.submodel() == submodelG2 {
-		_, err := g.moveG2(ctx, fullyOpenPosition)
+	m, err := g.moveFromParams(extra, fullyOpenPosition)
+	if err != nil {
 		return err
 	}
-
-	_, err := g.goToPosition(ctx, fullyOpenPosition)
+	_, err = g.moveTo(ctx, m)
 	return err
 }
 
@@ -414,11 +767,13 @@ func (g *myGripper) IsHoldingSomething(
 		if err != nil {
 			return gripper.HoldingStatus{}, err
 		}
-		meta := map[string]any{"status": status}
+		meta := g.holdingMeta()
+		meta["status"] = status
 		// Position is best-effort here: it is useful telemetry but must not turn
 		// a good holding answer into an error.
 		if pos, err := g.getPosition(ctx); err == nil {
 			meta["position"] = pos
+			meta[gripperWidthMMKey] = gripperWidthMM(g.submodel(), pos)
 		} else {
 			g.logger.Debugf("gripper position read failed during IsHoldingSomething: %v", err)
 		}
@@ -435,14 +790,24 @@ func (g *myGripper) IsHoldingSomething(
 
 	isHoldingSomething := pos > fullyClosedThreshold && pos < fullyOpenThreshold
 
+	meta := g.holdingMeta()
+	meta["position"] = pos
+	meta[gripperWidthMMKey] = gripperWidthMM(g.submodel(), pos)
 	return gripper.HoldingStatus{
 		IsHoldingSomething: isHoldingSomething,
-		Meta: map[string]any{
-			"position": pos,
-		},
+		Meta:               meta,
 	}, nil
 }
 
+// holdingMeta starts the IsHoldingSomething metadata with the last grasp report.
+func (g *myGripper) holdingMeta() map[string]any {
+	meta := map[string]any{}
+	if r := g.lastGraspReport(); r != nil {
+		meta[graspReportKey] = r.toMap()
+	}
+	return meta
+}
+
 // gripperForceControlRegs is the FnCxx block: enable, speed, force, position
 // high, position low. Writing it applies force atomically with the move — the G1
 // has no equivalent, which is also how the two are told apart.
@@ -469,32 +834,60 @@ func writeForceControlBlock(ctx context.Context, x *xArm, speed, force uint16, p
 
 // moveG2 issues the force block-write and waits on the status register,
 // returning the status that ended the move.
-func (g *myGripper) moveG2(ctx context.Context, goal int) (uint16, error) {
+func (g *myGripper) moveG2(ctx context.Context, m gripperMove) (uint16, error) {
 	g.goToPositionLock.Lock()
 	defer g.goToPositionLock.Unlock()
 
 	g.isMoving.Store(true)
 	defer g.isMoving.Store(false)
+	g.releaseHold()
 
 	x, err := g.bus()
 	if err != nil {
 		return 0, err
 	}
-	if err := writeForceControlBlock(ctx, x, g.speed, g.force, uint32(goal)); err != nil { //nolint:gosec // goal is 0..850.
+	speed, force := g.speed, g.force
+	if m.speed != 0 {
+		speed = m.speed
+	}
+	if m.force != 0 {
+		force = m.force
+	}
+	if err := writeForceControlBlock(ctx, x, speed, force, uint32(m.goal)); err != nil { //nolint:gosec // goal is 0..850.
 		return 0, err
 	}
 
 	return g.waitForStatus(ctx, gripperStatusTimeout)
 }
 
-// waitForStatus mirrors _check_gripper_status in both UFactory SDKs: a move is
+// waitForStatus waits on the gripper status register for the move just issued; see
+// waitForGripperStatus.
+func (g *myGripper) waitForStatus(ctx context.Context, timeout time.Duration) (uint16, error) {
+	x, err := g.bus()
+	if err != nil {
+		return 0, err
+	}
+	return waitForGripperStatus(ctx, x, g.name, timeout)
+}
+
+func (g *myGripper) getStatus(ctx context.Context) (uint16, error) {
+	x, err := g.bus()
+	if err != nil {
+		return 0, err
+	}
+	return x.getGripperStatus(ctx)
+}
+
+// waitForGripperStatus mirrors _check_gripper_status in both UFactory SDKs: a move is
 // only complete once the controller has reported IS_MOTION and then settled back
 // to IS_STOP or IS_DETECTED. Treating the first idle reading as "done" is what
 // forced callers to sleep before IsHoldingSomething — immediately after the
 // write the gripper has not begun moving, so its status and position still
 // describe the previous pose. If motion never starts (already at the goal), the
 // SDKs give up after 20 polls and call it done; we do the same.
-func (g *myGripper) waitForStatus(ctx context.Context, timeout time.Duration) (uint16, error) {
+//
+// The G2 and the BIO gripper share the status register layout, so both use this.
+func waitForGripperStatus(ctx context.Context, x *xArm, name resource.Name, timeout time.Duration) (uint16, error) {
 	const pollInterval = 100 * time.Millisecond
 	const notStartedPolls = 20
 
@@ -508,12 +901,14 @@ func (g *myGripper) waitForStatus(ctx context.Context, timeout time.Duration) (u
 			return status, ctx.Err()
 		}
 		var err error
-		if status, err = g.getStatus(ctx); err != nil {
+		if status, err = x.getGripperStatus(ctx); err != nil {
 			return status, err
 		}
 		switch status & gripperStateMask {
 		case gripperStateFault:
-			return status, fmt.Errorf("gripper reported a fault (status 0x%04x)", status)
+			err := fmt.Errorf("gripper reported a fault (status 0x%04x)", status)
+			x.recordEvent(ctx, armEvent{Type: eventTypeGripperFault, Message: fmt.Sprintf("%s: %v", name.ShortName(), err)})
+			return status, err
 		case gripperStateMotion:
 			started = true
 		default: // gripperStateStop or gripperStateDetected
@@ -529,31 +924,29 @@ func (g *myGripper) waitForStatus(ctx context.Context, timeout time.Duration) (u
 	return status, fmt.Errorf("gripper move did not complete within %s (status 0x%04x)", timeout, status)
 }
 
-func (g *myGripper) getStatus(ctx context.Context) (uint16, error) {
-	x, err := g.bus()
-	if err != nil {
-		return 0, err
-	}
-	r, err := x.readGripperRegisters(ctx, standardGripperStatusReg, 1)
-	if err != nil {
-		return 0, err
-	}
-	if r.exception != 0 {
-		return 0, fmt.Errorf("gripper status read rejected with Modbus exception 0x%02X (%v)", r.exception, r.params)
-	}
-	w := r.words()
-	if len(w) != 1 {
-		return 0, fmt.Errorf("bad gripper status response %v", r.params)
+// moveTo makes m with the generation's own move: the force block-write on a G2,
+// the plain position move on a G1. It returns where the jaws stopped.
+func (g *myGripper) moveTo(ctx context.Context, m gripperMove) (int, error) {
+	if g.submodel() == submodelG2 {
+		_, err := g.moveG2(ctx, m)
+		if err != nil {
+			return 0, err
+		}
+		return g.getPosition(ctx)
 	}
-	return w[0], nil
+	return g.goToPosition(ctx, m)
 }
 
-func (g *myGripper) goToPosition(ctx context.Context, goal int) (int, error) {
+// goToPosition is the Fn700 position move. A per-move speed is written to Fn303
+// for the move and the previous speed put back after it.
+func (g *myGripper) goToPosition(ctx context.Context, m gripperMove) (int, error) {
+	goal := m.goal
 	g.goToPositionLock.Lock()
 	defer g.goToPositionLock.Unlock()
 
 	g.isMoving.Store(true)
 	defer g.isMoving.Store(false)
+	g.releaseHold()
 
 	x, err := g.bus()
 	if err != nil {
@@ -562,6 +955,20 @@ func (g *myGripper) goToPosition(ctx context.Context, goal int) (int, error) {
 	if err := x.setupGripper(ctx); err != nil {
 		return 0, err
 	}
+	if m.speed != 0 {
+		prev, err := x.getGripperSpeed(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if err := x.setGripperSpeed(ctx, m.speed); err != nil {
+			return 0, err
+		}
+		defer func() {
+			if err := x.setGripperSpeed(ctx, prev); err != nil {
+				g.logger.Warnf("gripper: cannot restore speed %d after a move: %v", prev, err)
+			}
+		}()
+	}
 	if err := x.setGripperPosition(ctx, uint32(goal)); err != nil { //nolint:gosec // goal is 0..850.
 		return 0, err
 	}
@@ -617,6 +1024,9 @@ func (g *myGripper) Name() resource.Name {
 }
 
 func (g *myGripper) Close(ctx context.Context) error {
+	if g.workers != nil {
+		g.workers.Stop()
+	}
 	return g.Stop(ctx, nil)
 }
 
@@ -626,11 +1036,18 @@ func (g *myGripper) DoCommand(ctx context.Context, cmd map[string]any) (map[stri
 		if err != nil {
 			return nil, err
 		}
-		return map[string]any{"pos": pos}, nil
+		return map[string]any{"pos": pos, gripperWidthMMKey: gripperWidthMM(g.submodel(), pos)}, nil
+	}
+	if cmd[getGraspReportKey] == true {
+		r := g.lastGraspReport()
+		if r == nil {
+			return nil, fmt.Errorf("%s: the gripper has not grasped anything yet", getGraspReportKey)
+		}
+		return r.toMap(), nil
 	}
 	if posF, ok := cmd["set"].(float64); ok {
 		pos := int(posF)
-		_, err := g.goToPosition(ctx, pos)
+		_, err := g.goToPosition(ctx, gripperMove{goal: pos})
 		if err != nil {
 			return nil, err
 		}
@@ -640,13 +1057,52 @@ func (g *myGripper) DoCommand(ctx context.Context, cmd map[string]any) (map[stri
 		}
 		return map[string]interface{}{"position": pos}, nil
 	}
-	if _, ok := cmd[setGripperSpeedKey]; ok {
-		return g.arm.DoCommand(ctx, cmd)
+	if mm, ok := cmd[setGripperWidthMMKey].(float64); ok {
+		goal, err := gripperWidthPulses(g.submodel(), mm)
+		if err != nil {
+			return nil, err
+		}
+		pos, err := g.moveTo(ctx, gripperMove{goal: goal})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"position": pos, gripperWidthMMKey: gripperWidthMM(g.submodel(), pos)}, nil
 	}
-	if _, ok := cmd[getGripperSpeedKey]; ok {
-		return g.arm.DoCommand(ctx, cmd)
+	if mmPerSec, ok := cmd[setGripperSpeedMMPerSecKey].(float64); ok {
+		speed, err := gripperSpeedRaw(g.submodel(), mmPerSec)
+		if err != nil {
+			return nil, err
+		}
+		cmd = map[string]any{setGripperSpeedKey: float64(speed)}
+	}
+	_, set := cmd[setGripperSpeedKey]
+	if _, get := cmd[getGripperSpeedKey]; set || get {
+		resp, err := g.arm.DoCommand(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		if speed, ok := resp[gripperSpeedKey].(float64); ok {
+			// The G2 takes its speed with each move rather than from Fn303, so
+			// keep the one its moves send in step.
+			if set {
+				g.goToPositionLock.Lock()
+				g.speed = uint16(speed)
+				g.goToPositionLock.Unlock()
+			}
+			resp[gripperSpeedMMPerSecKey] = gripperSpeedMMPerSec(g.submodel(), uint16(speed))
+		}
+		return resp, nil
+	}
+	if val, ok := cmd[graspKey]; ok {
+		params, ok := val.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a map of move keys, got %T", graspKey, val)
+		}
+		return g.grasp(ctx, params)
 	}
 	if _, ok := cmd[grabWithTorqueKey]; ok {
+		// Left to the arm as it always was; grasp is the gripper's own force-limited close.
+		g.releaseHold()
 		g.isMoving.Store(true)
 		defer g.isMoving.Store(false)
 		return g.arm.DoCommand(ctx, cmd)
@@ -654,6 +1110,24 @@ func (g *myGripper) DoCommand(ctx context.Context, cmd map[string]any) (map[stri
 	return map[string]any{}, nil
 }
 
+// grasp is a force-limited close on a G2: a move built from params, to fully
+// closed unless they set a target, that reports whether it stopped on an object
+// and where.
+func (g *myGripper) grasp(ctx context.Context, params map[string]any) (map[string]any, error) {
+	if g.submodel() != submodelG2 {
+		return nil, fmt.Errorf("%s needs a G2 gripper; a G1 has no force control", graspKey)
+	}
+	m, err := g.moveFromParams(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	r, err := g.close(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	return r.toMap(), nil
+}
+
 func (g *myGripper) IsMoving(context.Context) (bool, error) {
 	return g.isMoving.Load(), nil
 }
@@ -663,26 +1137,54 @@ func (g *myGripper) Stop(context.Context, map[string]any) error {
 	return nil
 }
 
+// Geometries places the jaws where they are. If their position can't be read they are drawn at the
+// full stroke, the most room they can take up.
 func (g *myGripper) Geometries(ctx context.Context, _ map[string]any) ([]spatialmath.Geometry, error) {
-	if g.useURDFs {
-		gif, err := g.mf.Geometries(make([]referenceframe.Input, len(g.mf.DoF())))
-		if err != nil {
-			return nil, err
-		}
-		return gif.Geometries(), nil
+	inputs, err := g.CurrentInputs(ctx)
+	if err != nil {
+		g.logger.Debugf("cannot read the gripper position for its geometries, drawing it fully open: %v", err)
+		inputs = []referenceframe.Input{gripperStrokeMM(g.submodel())}
 	}
-	return standardGripperGeometries(g.submodel())
+	gif, err := g.mf.Geometries(inputs)
+	if err != nil {
+		return nil, err
+	}
+	return gif.Geometries(), nil
 }
 
 func (g *myGripper) Kinematics(ctx context.Context) (referenceframe.Model, error) {
 	return g.mf, nil
 }
 
+// CurrentInputs returns the jaw width in mm, read from Fn702.
 func (g *myGripper) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
-	return []referenceframe.Input{}, nil
+	if len(g.mf.DoF()) == 0 {
+		return []referenceframe.Input{}, nil
+	}
+	pos, err := g.getPosition(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []referenceframe.Input{gripperWidthMM(g.submodel(), pos)}, nil
 }
 
-func (g *myGripper) GoToInputs(ctx context.Context, inputs ...[]referenceframe.Input) error {
+// GoToInputs opens or closes the jaws to each width in mm in turn.
+func (g *myGripper) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	if len(g.mf.DoF()) == 0 {
+		return nil
+	}
+	for _, step := range inputSteps {
+		if len(step) != 1 {
+			return fmt.Errorf("gripper has 1 joint, got %d inputs", len(step))
+		}
+		goal, err := gripperWidthPulses(g.submodel(), step[0])
+		if err != nil {
+			return err
+		}
+		if _, err := g.moveTo(ctx, gripperMove{goal: goal}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -690,27 +1192,82 @@ func (g *myGripper) Status(_ context.Context) (map[string]any, error) {
 	return map[string]any{}, nil
 }
 
-func standardGripperGeometries(version string) ([]spatialmath.Geometry, error) {
-	caseBoxSize := r3.Vector{X: 50, Y: 100, Z: 100}
-	clawSize := r3.Vector{X: 40, Y: 170, Z: 105}
+// Frame names in the standard gripper's kinematic model. The jaw width joint is
+// the model's one input; each jaw mimics half of it, in opposite directions.
+const (
+	gripperCaseFrame      = "case-gripper"
+	gripperJawWidthFrame  = "jaw_width"
+	gripperLeftJawFrame   = "left_jaw"
+	gripperRightJawFrame  = "right_jaw"
+	gripperLeftClawFrame  = "left-claw"
+	gripperRightClawFrame = "right-claw"
+)
+
+// standardGripperDims are the hand-authored boxes for a generation: the case, and
+// the space the two jaws sweep, which is widest fully open.
+type standardGripperDims struct {
+	caseBox, claws r3.Vector
+	// strokeMM is the jaw opening at full stroke, from gripperWidthTables.
+	strokeMM float64
+}
+
+func standardGripperDimensions(version string) standardGripperDims {
 	if version == submodelG2 {
-		caseBoxSize = r3.Vector{X: 75, Y: 110, Z: 110}
-		clawSize = r3.Vector{X: 45, Y: 120, Z: 112}
+		return standardGripperDims{
+			caseBox:  r3.Vector{X: 75, Y: 110, Z: 110},
+			claws:    r3.Vector{X: 45, Y: 120, Z: 112},
+			strokeMM: gripperStrokeMM(submodelG2),
+		}
 	}
+	return standardGripperDims{
+		caseBox:  r3.Vector{X: 50, Y: 100, Z: 100},
+		claws:    r3.Vector{X: 40, Y: 170, Z: 105},
+		strokeMM: gripperStrokeMM(submodelG1),
+	}
+}
 
-	caseBox, err := spatialmath.NewBox(
-		spatialmath.NewPoseFromPoint(r3.Vector{X: 0, Y: 0, Z: caseBoxSize.Z / -2}),
-		caseBoxSize, "case-gripper")
-	if err != nil {
-		return nil, err
+// standardGripperModel builds the gripper's kinematics: the case, fixed, and two
+// jaws that move with the jaw width joint. At full stroke the jaws fill the claw
+// box the gripper reported before it had a joint.
+func standardGripperModel(version string) (referenceframe.Model, error) {
+	d := standardGripperDimensions(version)
+	jaw := r3.Vector{X: d.claws.X, Y: (d.claws.Y - d.strokeMM) / 2, Z: d.claws.Z}
+	jawZ := 50 - d.claws.Z/2
+	box := func(size, offset r3.Vector) *spatialmath.GeometryConfig {
+		return &spatialmath.GeometryConfig{Type: spatialmath.BoxType, X: size.X, Y: size.Y, Z: size.Z, TranslationOffset: offset}
 	}
-	claws, err := spatialmath.NewBox(
-		spatialmath.NewPoseFromPoint(r3.Vector{Z: 50 + (clawSize.Z / -2)}),
-		clawSize, "claws")
-	if err != nil {
-		return nil, err
+	jawJoint := func(id string, mimic float64) referenceframe.JointConfig {
+		return referenceframe.JointConfig{
+			ID:     id,
+			Type:   referenceframe.PrismaticJoint,
+			Parent: gripperCaseFrame,
+			Axis:   spatialmath.AxisConfig{Y: 1},
+			Mimic:  &referenceframe.MimicConfig{Joint: gripperJawWidthFrame, ValueMultiplier: mimic},
+		}
 	}
-	return []spatialmath.Geometry{caseBox, claws}, nil
+
+	cfg := &referenceframe.ModelConfigJSON{
+		Name: ModelNameGripper,
+		Links: []referenceframe.LinkConfig{
+			{ID: gripperCaseFrame, Parent: referenceframe.World, Geometry: box(d.caseBox, r3.Vector{Z: d.caseBox.Z / -2})},
+			{ID: gripperLeftClawFrame, Parent: gripperLeftJawFrame, Geometry: box(jaw, r3.Vector{Y: jaw.Y / 2, Z: jawZ})},
+			{ID: gripperRightClawFrame, Parent: gripperRightJawFrame, Geometry: box(jaw, r3.Vector{Y: jaw.Y / -2, Z: jawZ})},
+		},
+		Joints: []referenceframe.JointConfig{
+			{
+				ID:     gripperJawWidthFrame,
+				Type:   referenceframe.PrismaticJoint,
+				Parent: gripperCaseFrame,
+				Axis:   spatialmath.AxisConfig{Y: 1},
+				Max:    d.strokeMM,
+			},
+			jawJoint(gripperLeftJawFrame, 0.5),
+			jawJoint(gripperRightJawFrame, -0.5),
+		},
+		// The gripper's frame stays at the case, however wide the jaws are.
+		OutputFrames: []string{gripperCaseFrame},
+	}
+	return parseModelConfig(ModelNameGripper, cfg)
 }
 
 // liteGripperGeometries — hand-authored boxes for the Lite gripper.
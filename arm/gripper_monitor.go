@@ -0,0 +1,210 @@
+package arm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goutils "go.viam.com/utils"
+)
+
+// The slip monitor watches an object the standard gripper is holding. After a Grab or grasp that
+// ended on an object it polls the gripper, and raises a gripper_slip event when the object slips
+// (the jaws close further than drift_mm past where they stopped) or is dropped (a G2 loses its
+// detected bit, a G1 closes all the way). Any move of the jaws ends the watch.
+
+const (
+	getGraspReportKey = "get_grasp_report"
+	graspReportKey    = "grasp_report"
+
+	defaultSlipIntervalSec = 0.2
+	defaultSlipDriftMM     = 2.
+)
+
+// SlipMonitorConfig turns on the slip monitor for the standard gripper.
+type SlipMonitorConfig struct {
+	// IntervalSec is how often a held object is checked. 0 means defaultSlipIntervalSec.
+	IntervalSec float64 `json:"interval_sec,omitempty"`
+	// DriftMM is how far the jaws may close past the grasp before it counts as a slip. 0 means
+	// defaultSlipDriftMM.
+	DriftMM float64 `json:"drift_mm,omitempty"`
+	// StopArm stops the arm when the object slips or is dropped.
+	StopArm bool `json:"stop_arm,omitempty"`
+}
+
+func (cfg *SlipMonitorConfig) validate() error {
+	if cfg.IntervalSec < 0 {
+		return fmt.Errorf("slip_monitor.interval_sec must not be negative, got %v", cfg.IntervalSec)
+	}
+	if cfg.DriftMM < 0 {
+		return fmt.Errorf("slip_monitor.drift_mm must not be negative, got %v", cfg.DriftMM)
+	}
+	return nil
+}
+
+func (cfg *SlipMonitorConfig) interval() time.Duration {
+	if cfg.IntervalSec == 0 {
+		return time.Duration(defaultSlipIntervalSec * float64(time.Second))
+	}
+	return time.Duration(cfg.IntervalSec * float64(time.Second))
+}
+
+func (cfg *SlipMonitorConfig) driftMM() float64 {
+	if cfg.DriftMM == 0 {
+		return defaultSlipDriftMM
+	}
+	return cfg.DriftMM
+}
+
+// graspReport describes how the last Grab or grasp went. The gripper exposes no motor current, so
+// the report has none.
+type graspReport struct {
+	holding bool
+	// status is the G2 status register the move ended on. A G1 has none.
+	status      *uint16
+	position    int
+	widthMM     float64
+	timeToClose time.Duration
+}
+
+func (r *graspReport) toMap() map[string]any {
+	m := map[string]any{
+		"holding":           r.holding,
+		"position":          r.position,
+		gripperWidthMMKey:   r.widthMM,
+		"time_to_close_sec": r.timeToClose.Seconds(),
+	}
+	if r.status != nil {
+		m["status"] = *r.status
+	}
+	return m
+}
+
+// gripperHold is what the slip monitor is watching. gen changes whenever the hold starts or ends,
+// so a check that raced a move can tell its reading is stale.
+type gripperHold struct {
+	held     bool
+	gen      uint64
+	position int
+}
+
+type slipResult string
+
+const (
+	slipNone    slipResult = ""
+	slipSlipped slipResult = "slipped"
+	slipDropped slipResult = "dropped"
+)
+
+// evaluateSlip compares a reading of a held object against the position the grasp ended at. A
+// G2 answers from its status register: stopped without an object is a drop, and a move is left
+// to settle. A G1 only has the jaw position, so closing fully is a drop.
+func evaluateSlip(version string, baseline, pos int, status uint16, driftMM float64) slipResult {
+	if version == submodelG2 {
+		switch status & gripperStateMask {
+		case gripperStateStop:
+			return slipDropped
+		case gripperStateDetected:
+		default:
+			return slipNone
+		}
+	} else if pos <= fullyClosedThreshold {
+		return slipDropped
+	}
+	if gripperWidthMM(version, baseline)-gripperWidthMM(version, pos) > driftMM {
+		return slipSlipped
+	}
+	return slipNone
+}
+
+// recordGrasp keeps r as the last grasp report and, if it ended on an object, starts watching it.
+func (g *myGripper) recordGrasp(r *graspReport) {
+	g.holdMu.Lock()
+	defer g.holdMu.Unlock()
+	g.lastGrasp = r
+	g.hold = gripperHold{held: r.holding, gen: g.hold.gen + 1, position: r.position}
+}
+
+// releaseHold ends the watch on a held object. Every move calls it before touching the jaws.
+func (g *myGripper) releaseHold() {
+	g.holdMu.Lock()
+	defer g.holdMu.Unlock()
+	if g.hold.held {
+		g.hold = gripperHold{gen: g.hold.gen + 1}
+	}
+}
+
+func (g *myGripper) lastGraspReport() *graspReport {
+	g.holdMu.Lock()
+	defer g.holdMu.Unlock()
+	return g.lastGrasp
+}
+
+// monitorSlip checks the held object every interval until ctx is done.
+func (g *myGripper) monitorSlip(ctx context.Context, cfg *SlipMonitorConfig) {
+	interval := cfg.interval()
+	for goutils.SelectContextOrWait(ctx, interval) {
+		if err := g.checkSlip(ctx, cfg); err != nil && ctx.Err() == nil {
+			g.logger.Debugf("gripper slip check failed: %v", err)
+		}
+	}
+}
+
+func (g *myGripper) checkSlip(ctx context.Context, cfg *SlipMonitorConfig) error {
+	// A move in progress owns the jaws; it ends the hold itself.
+	if !g.goToPositionLock.TryLock() {
+		return nil
+	}
+	defer g.goToPositionLock.Unlock()
+
+	g.holdMu.Lock()
+	hold := g.hold
+	g.holdMu.Unlock()
+	if !hold.held {
+		return nil
+	}
+
+	var status uint16
+	if g.submodel() == submodelG2 {
+		var err error
+		if status, err = g.getStatus(ctx); err != nil {
+			return err
+		}
+	}
+	pos, err := g.getPosition(ctx)
+	if err != nil {
+		return err
+	}
+	result := evaluateSlip(g.submodel(), hold.position, pos, status, cfg.driftMM())
+	if result == slipNone {
+		return nil
+	}
+
+	g.holdMu.Lock()
+	if g.hold.gen != hold.gen {
+		g.holdMu.Unlock()
+		return nil
+	}
+	if result == slipDropped {
+		g.hold = gripperHold{gen: hold.gen + 1}
+	} else {
+		// Watch for the next slip from where this one stopped.
+		g.hold.position = pos
+	}
+	g.holdMu.Unlock()
+
+	x, err := g.bus()
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("%s: object %s, jaws at %.1f mm after grasping at %.1f mm", g.name.ShortName(), result,
+		gripperWidthMM(g.submodel(), pos), gripperWidthMM(g.submodel(), hold.position))
+	g.logger.Warn(msg)
+	x.recordEvent(ctx, armEvent{Type: eventTypeGripperSlip, Message: msg})
+	if cfg.StopArm {
+		if err := x.Stop(ctx, nil); err != nil {
+			return fmt.Errorf("stopping the arm after the object %s: %w", result, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package arm
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+)
+
+func TestSlipMonitorConfigValidate(t *testing.T) {
+	_, _, err := (&GripperConfig{Arm: "a", SlipMonitor: &SlipMonitorConfig{}}).Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+	_, _, err = (&GripperConfig{Arm: "a", SlipMonitor: &SlipMonitorConfig{IntervalSec: -1}}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&GripperConfig{Arm: "a", SlipMonitor: &SlipMonitorConfig{DriftMM: -1}}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	cfg := &SlipMonitorConfig{}
+	test.That(t, cfg.interval(), test.ShouldEqual, 200*time.Millisecond)
+	test.That(t, cfg.driftMM(), test.ShouldEqual, defaultSlipDriftMM)
+	cfg = &SlipMonitorConfig{IntervalSec: 1, DriftMM: 5}
+	test.That(t, cfg.interval(), test.ShouldEqual, time.Second)
+	test.That(t, cfg.driftMM(), test.ShouldEqual, 5.)
+}
+
+func TestEvaluateSlip(t *testing.T) {
+	// G1: 850 positions over 86 mm, so 2 mm is about 20 positions.
+	test.That(t, evaluateSlip(submodelG1, 400, 390, 0, 2), test.ShouldEqual, slipNone)
+	test.That(t, evaluateSlip(submodelG1, 400, 370, 0, 2), test.ShouldEqual, slipSlipped)
+	test.That(t, evaluateSlip(submodelG1, 400, 5, 0, 2), test.ShouldEqual, slipDropped)
+
+	// G2: the status register decides whether the object is still there.
+	test.That(t, evaluateSlip(submodelG2, 400, 400, gripperStateDetected, 2), test.ShouldEqual, slipNone)
+	test.That(t, evaluateSlip(submodelG2, 400, 370, gripperStateDetected, 2), test.ShouldEqual, slipSlipped)
+	test.That(t, evaluateSlip(submodelG2, 400, 400, gripperStateStop, 2), test.ShouldEqual, slipDropped)
+	test.That(t, evaluateSlip(submodelG2, 400, 200, gripperStateMotion, 2), test.ShouldEqual, slipNone)
+}
+
+func TestGripperHold(t *testing.T) {
+	g := &myGripper{}
+	test.That(t, g.lastGraspReport(), test.ShouldBeNil)
+
+	status := gripperStateDetected
+	r := &graspReport{holding: true, status: &status, position: 425, widthMM: 42, timeToClose: 1500 * time.Millisecond}
+	g.recordGrasp(r)
+	test.That(t, g.lastGraspReport(), test.ShouldEqual, r)
+	test.That(t, g.hold.held, test.ShouldBeTrue)
+	test.That(t, g.hold.position, test.ShouldEqual, 425)
+	gen := g.hold.gen
+
+	// A move ends the watch but keeps the report.
+	g.releaseHold()
+	test.That(t, g.hold.held, test.ShouldBeFalse)
+	test.That(t, g.hold.gen, test.ShouldBeGreaterThan, gen)
+	test.That(t, g.lastGraspReport(), test.ShouldEqual, r)
+
+	test.That(t, r.toMap(), test.ShouldResemble, map[string]any{
+		"holding":           true,
+		"status":            gripperStateDetected,
+		"position":          425,
+		gripperWidthMMKey:   42.,
+		"time_to_close_sec": 1.5,
+	})
+	// A G1 has no status to report, and a grasp that closed on nothing is not watched.
+	r = &graspReport{position: 2}
+	g.recordGrasp(r)
+	test.That(t, g.hold.held, test.ShouldBeFalse)
+	test.That(t, r.toMap(), test.ShouldNotContainKey, "status")
+}
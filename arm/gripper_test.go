@@ -1,8 +1,18 @@
 package arm
 
 import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
 	"testing"
+	"time"
 
+	"go.viam.com/rdk/components/gripper"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
 	"go.viam.com/test"
 )
 
@@ -28,3 +38,324 @@ func TestGripperConfigValidateVersionAndForce(t *testing.T) {
 	test.That(t, err, test.ShouldNotBeNil)
 	test.That(t, err.Error(), test.ShouldContainSubstring, "gripper_force")
 }
+
+func TestStandardGripperModel(t *testing.T) {
+	for _, version := range []string{submodelG1, submodelG2} {
+		t.Run(version, func(t *testing.T) {
+			dims := standardGripperDimensions(version)
+			mf, err := standardGripperModel(version)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, mf.DoF(), test.ShouldHaveLength, 1)
+			test.That(t, mf.DoF()[0].Max, test.ShouldEqual, dims.strokeMM)
+
+			// Clients rebuild the model from the kinematics file, mimic joints included.
+			rebuilt, err := referenceframe.KinematicModelFromProtobuf(ModelNameGripper, referenceframe.KinematicModelToProtobuf(mf))
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, rebuilt.DoF(), test.ShouldResemble, mf.DoF())
+
+			// The gripper's frame does not move with the jaws.
+			pose, err := rebuilt.Transform([]referenceframe.Input{dims.strokeMM})
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, spatialmath.PoseAlmostEqual(pose, spatialmath.NewZeroPose()), test.ShouldBeTrue)
+
+			jaws := func(width float64) (spatialmath.Geometry, spatialmath.Geometry) {
+				gif, err := rebuilt.Geometries([]referenceframe.Input{width})
+				test.That(t, err, test.ShouldBeNil)
+				byLabel := map[string]spatialmath.Geometry{}
+				for _, g := range gif.Geometries() {
+					byLabel[g.Label()] = g
+				}
+				test.That(t, byLabel, test.ShouldHaveLength, 3)
+				return byLabel[ModelNameGripper+":"+gripperLeftClawFrame], byLabel[ModelNameGripper+":"+gripperRightClawFrame]
+			}
+
+			// Fully open, the jaws fill the claw box the gripper reported before it had a joint.
+			left, right := jaws(dims.strokeMM)
+			jawY := (dims.claws.Y - dims.strokeMM) / 2
+			test.That(t, left.Pose().Point().Y, test.ShouldAlmostEqual, dims.claws.Y/2-jawY/2)
+			test.That(t, right.Pose().Point().Y, test.ShouldAlmostEqual, -dims.claws.Y/2+jawY/2)
+
+			// Closed, they meet in the middle.
+			left, right = jaws(0)
+			test.That(t, left.Pose().Point().Y-jawY/2, test.ShouldAlmostEqual, 0)
+			test.That(t, right.Pose().Point().Y+jawY/2, test.ShouldAlmostEqual, 0)
+		})
+	}
+}
+
+func TestGripperMoveFromParams(t *testing.T) {
+	g2 := &myGripper{detected: detectedGripper{kind: gripperKindStandard, submodel: submodelG2}}
+	g1 := &myGripper{detected: detectedGripper{kind: gripperKindStandard, submodel: submodelG1}}
+
+	// No keys keeps the default target and the configured speed and force.
+	m, err := g2.moveFromParams(nil, fullyOpenPosition)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, m, test.ShouldResemble, gripperMove{goal: fullyOpenPosition})
+
+	m, err = g2.moveFromParams(map[string]any{"position": 100., "speed": 3000., "force": 80.}, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, m, test.ShouldResemble, gripperMove{goal: 100, speed: 3000, force: 80})
+
+	m, err = g2.moveFromParams(map[string]any{"width_mm": 42., "speed_mm_per_sec": 90.}, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, m, test.ShouldResemble, gripperMove{goal: 425, speed: defaultGripperSpeedG2})
+
+	for _, bad := range []map[string]any{
+		{"position": 100., "width_mm": 10.},
+		{"speed": 100., "speed_mm_per_sec": 10.},
+		{"position": 851.},
+		{"speed": 0.},
+		{"force": 0.},
+		{"force": 101.},
+		{"width_mm": 90.},
+		{"position": "100"},
+	} {
+		_, err := g2.moveFromParams(bad, 0)
+		test.That(t, err, test.ShouldNotBeNil)
+	}
+
+	// A G1 takes the target and speed, but has no force control.
+	m, err = g1.moveFromParams(map[string]any{"width_mm": 43., "speed": 1500.}, g1GrabPosition)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, m, test.ShouldResemble, gripperMove{goal: 425, speed: 1500})
+	_, err = g1.moveFromParams(map[string]any{"force": 50.}, g1GrabPosition)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = g1.grasp(context.Background(), nil)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestGripperLiteConfigValidate(t *testing.T) {
+	_, _, err := (&GripperConfig{Arm: "a", HoldingInputs: []int{0, 1}, MoveTimeSec: 0.5, SettleTimeSec: 0.1}).Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+
+	_, _, err = (&GripperConfig{Arm: "a", HoldingInputs: []int{5}}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "holding_inputs")
+	_, _, err = (&GripperConfig{Arm: "a", MoveTimeSec: -1}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&GripperConfig{Arm: "a", SettleTimeSec: -1}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestLiteHoldingFromInputs(t *testing.T) {
+	test.That(t, liteHoldingFromInputs(0x0001, []int{0}, false), test.ShouldBeTrue)
+	test.That(t, liteHoldingFromInputs(0x0000, []int{0}, false), test.ShouldBeFalse)
+	// Any one configured input is enough.
+	test.That(t, liteHoldingFromInputs(0x0002, []int{0, 1}, false), test.ShouldBeTrue)
+	// Pin 3 is bit 2, as for the vacuum gripper's v2 sensor.
+	test.That(t, liteHoldingFromInputs(0x0004, []int{3}, false), test.ShouldBeTrue)
+	test.That(t, liteHoldingFromInputs(0x0008, []int{3}, false), test.ShouldBeFalse)
+
+	test.That(t, liteHoldingFromInputs(0x0000, []int{0}, true), test.ShouldBeTrue)
+	test.That(t, liteHoldingFromInputs(0x0001, []int{0}, true), test.ShouldBeFalse)
+}
+
+func TestStandardGripperGeometriesWithoutPosition(t *testing.T) {
+	mf, err := standardGripperModel(submodelG2)
+	test.That(t, err, test.ShouldBeNil)
+	// No arm to read the position through, so the jaws are drawn at the full stroke.
+	g := &myGripper{mf: mf, detected: detectedGripper{kind: gripperKindStandard, submodel: submodelG2}, logger: logging.NewTestLogger(t)}
+	geoms, err := g.Geometries(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+
+	open, err := mf.Geometries([]referenceframe.Input{gripperStrokeMM(submodelG2)})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, geoms, test.ShouldResemble, open.Geometries())
+}
+
+// fakeGripperController stands in for the controller's RS-485 passthrough with one gripper on the
+// tool bus. Writing a target position starts a move that the next motionReads status reads report
+// as in motion.
+type fakeGripperController struct {
+	mu          sync.Mutex
+	regs        map[uint16]uint16
+	motionReads int
+	moving      int
+	targets     []uint32
+}
+
+func startFakeGripperController(t *testing.T, c *fakeGripperController) string {
+	t.Helper()
+	var lc net.ListenConfig
+	ln, err := lc.Listen(context.Background(), "tcp", "127.0.0.1:0")
+	test.That(t, err, test.ShouldBeNil)
+	t.Cleanup(func() { test.That(t, ln.Close(), test.ShouldBeNil) })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go c.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (c *fakeGripperController) serve(conn net.Conn) {
+	defer conn.Close() //nolint:errcheck
+	for {
+		header := make([]byte, 7)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		params := make([]byte, binary.BigEndian.Uint16(header[4:6])-1)
+		if _, err := io.ReadFull(conn, params); err != nil {
+			return
+		}
+		resp := c.handle(params)
+		binary.BigEndian.PutUint16(header[4:6], uint16(len(resp)+1)) //nolint:gosec
+		if _, err := conn.Write(append(header, resp...)); err != nil {
+			return
+		}
+	}
+}
+
+// handle answers one passthrough request: host, slave, function, address, count, then the values of
+// a write.
+func (c *fakeGripperController) handle(req []byte) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	host, slave, function := req[0], req[1], req[2]
+	addr, count := binary.BigEndian.Uint16(req[3:5]), binary.BigEndian.Uint16(req[5:7])
+	if function == modbusWriteMultiple {
+		for i := range count {
+			c.regs[addr+i] = binary.BigEndian.Uint16(req[8+2*i:])
+		}
+		if addr == gripperTargetPosReg {
+			c.targets = append(c.targets, uint32(c.regs[addr])<<16|uint32(c.regs[addr+1]))
+			c.moving = c.motionReads
+		}
+		return append([]byte{0, host, slave, function}, req[3:7]...)
+	}
+	resp := []byte{0, host, slave, function, byte(2 * count)}
+	for i := range count {
+		v, ok := c.regs[addr+i]
+		if !ok {
+			return []byte{0, host, slave, function | 0x80, 0x02}
+		}
+		if addr+i == standardGripperStatusReg && c.moving > 0 {
+			c.moving--
+			v |= gripperStateMotion
+		}
+		resp = binary.BigEndian.AppendUint16(resp, v)
+	}
+	return resp
+}
+
+func (c *fakeGripperController) sentTargets() []uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]uint32(nil), c.targets...)
+}
+
+func newFakeBioGripperLite(t *testing.T, motionReads int) (*myGripperLite, *fakeGripperController) {
+	t.Helper()
+	logger := logging.NewTestLogger(t)
+	c := &fakeGripperController{motionReads: motionReads, regs: map[uint16]uint16{
+		standardGripperStatusReg: bioGripperEnabledMask | gripperStateStop,
+		gripperCurrentPosReg:     0,
+		gripperCurrentPosReg + 1: 90,
+	}}
+	x := &xArm{logger: logger, gripperConn: newModbusConn(startFakeGripperController(t, c), logger, nil)}
+	g := &myGripperLite{
+		name:     gripper.Named("lite"),
+		arm:      x,
+		detected: detectedGripper{kind: gripperKindBio, version: "2"},
+		logger:   logger,
+	}
+	return g, c
+}
+
+func TestGripperLiteMove(t *testing.T) {
+	ctx := context.Background()
+	var actions []any
+	var movingDuringCommand bool
+	a := &fakeArm{}
+	g := &myGripperLite{arm: a, moveTime: 10 * time.Millisecond, logger: logging.NewTestLogger(t)}
+	a.doFn = func(cmd map[string]any) (map[string]any, error) {
+		actions = append(actions, cmd[gripperLiteActionKey])
+		movingDuringCommand, _ = g.IsMoving(ctx)
+		return map[string]any{}, nil
+	}
+
+	test.That(t, g.Open(ctx, nil), test.ShouldBeNil)
+	test.That(t, actions, test.ShouldResemble, []any{gripperLiteActionOpen})
+	test.That(t, movingDuringCommand, test.ShouldBeTrue)
+	moving, err := g.IsMoving(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moving, test.ShouldBeFalse)
+}
+
+func TestGripperLiteStop(t *testing.T) {
+	ctx := context.Background()
+	var actions []any
+	var mu sync.Mutex
+	a := &fakeArm{}
+	a.doFn = func(cmd map[string]any) (map[string]any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		actions = append(actions, cmd[gripperLiteActionKey])
+		return map[string]any{}, nil
+	}
+	g := &myGripperLite{arm: a, moveTime: time.Minute, logger: logging.NewTestLogger(t)}
+
+	done := make(chan error)
+	go func() { done <- g.Open(ctx, nil) }()
+	for moving, _ := g.IsMoving(ctx); !moving; moving, _ = g.IsMoving(ctx) {
+		time.Sleep(time.Millisecond)
+	}
+	test.That(t, g.Stop(ctx, nil), test.ShouldBeNil)
+	test.That(t, <-done, test.ShouldBeError, errLiteGripperStopped)
+	mu.Lock()
+	test.That(t, actions, test.ShouldResemble, []any{gripperLiteActionOpen, gripperLiteActionStop})
+	mu.Unlock()
+	moving, err := g.IsMoving(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moving, test.ShouldBeFalse)
+}
+
+func TestGripperLiteBioMove(t *testing.T) {
+	ctx := context.Background()
+	g, c := newFakeBioGripperLite(t, 1)
+
+	// The BIO gripper gets its own position commands rather than the TGPIO toggles.
+	test.That(t, g.Open(ctx, nil), test.ShouldBeNil)
+	held, err := g.Grab(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, held, test.ShouldBeFalse)
+	test.That(t, c.sentTargets(), test.ShouldResemble, []uint32{bioGripperOpenPosition, bioGripperClosePosition})
+
+	// A gripper that has dropped its enable is set up again before it moves.
+	c.mu.Lock()
+	c.regs[standardGripperStatusReg] = gripperStateStop
+	c.mu.Unlock()
+	test.That(t, g.Open(ctx, nil), test.ShouldBeNil)
+	c.mu.Lock()
+	test.That(t, c.regs[gripperEnableReg], test.ShouldEqual, 1)
+	test.That(t, c.regs[gripperSpeedReg], test.ShouldEqual, defaultBioGripperSpeed)
+	test.That(t, c.regs[bioGripperForceReg], test.ShouldEqual, defaultBioGripperForce)
+	c.mu.Unlock()
+}
+
+func TestGripperLiteBioStop(t *testing.T) {
+	ctx := context.Background()
+	g, c := newFakeBioGripperLite(t, 1000)
+
+	done := make(chan error)
+	go func() { done <- g.Open(ctx, nil) }()
+	for len(c.sentTargets()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	moving, err := g.IsMoving(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moving, test.ShouldBeTrue)
+
+	// Stop ends the wait and holds the jaws where they are.
+	test.That(t, g.Stop(ctx, nil), test.ShouldBeNil)
+	test.That(t, <-done, test.ShouldBeError, errLiteGripperStopped)
+	test.That(t, c.sentTargets(), test.ShouldResemble, []uint32{bioGripperOpenPosition, 90})
+	moving, err = g.IsMoving(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moving, test.ShouldBeFalse)
+}
@@ -0,0 +1,113 @@
+package arm
+
+import (
+	"fmt"
+	"math"
+)
+
+// The standard gripper speaks raw register units on both generations: positions (Fn700/Fn702 and
+// the FnC03 target) run 0..gripperMaxPulses from closed to fully open, and speeds (Fn303 and FnC01)
+// 1..maxGripperSpeed. What those mean in mm and mm/s depends on the generation, so every conversion
+// goes through the tables here, and a better calibration only has to change them.
+
+const maxGripperSpeed = 5000
+
+// gripperUnitPoint pairs a raw register value with the physical value it stands for.
+type gripperUnitPoint struct {
+	raw, physical float64
+}
+
+// gripperUnitTable is a calibration, in ascending raw order, interpolated linearly between points.
+type gripperUnitTable []gripperUnitPoint
+
+// gripperWidthTables map positions to the jaw opening in mm.
+var gripperWidthTables = map[string]gripperUnitTable{
+	submodelG1: {{0, 0}, {gripperMaxPulses, 86}},
+	submodelG2: {{0, 0}, {gripperMaxPulses, 84}},
+}
+
+// gripperSpeedTables map speeds to the jaw speed in mm/s. The scale is linear up to the fastest
+// the jaws run: 150 mm/s on a G1, 225 mm/s on a G2.
+var gripperSpeedTables = map[string]gripperUnitTable{
+	submodelG1: {{0, 0}, {maxGripperSpeed, 150}},
+	submodelG2: {{0, 0}, {maxGripperSpeed, 225}},
+}
+
+// gripperUnitTables returns the tables for version. Anything but a G2 is a G1, as for the geometry.
+func gripperUnitTables(version string) (width, speed gripperUnitTable) {
+	if version == submodelG2 {
+		return gripperWidthTables[submodelG2], gripperSpeedTables[submodelG2]
+	}
+	return gripperWidthTables[submodelG1], gripperSpeedTables[submodelG1]
+}
+
+func interpolateGripperUnits(v, x0, x1, y0, y1 float64) float64 {
+	return y0 + (v-x0)*(y1-y0)/(x1-x0)
+}
+
+// toPhysical converts a raw value, clamped to the table's range.
+func (t gripperUnitTable) toPhysical(raw float64) float64 {
+	if raw <= t[0].raw {
+		return t[0].physical
+	}
+	for i := 1; i < len(t); i++ {
+		if raw <= t[i].raw {
+			return interpolateGripperUnits(raw, t[i-1].raw, t[i].raw, t[i-1].physical, t[i].physical)
+		}
+	}
+	return t[len(t)-1].physical
+}
+
+// toRaw converts a physical value, clamped to the table's range.
+func (t gripperUnitTable) toRaw(physical float64) float64 {
+	if physical <= t[0].physical {
+		return t[0].raw
+	}
+	for i := 1; i < len(t); i++ {
+		if physical <= t[i].physical {
+			return interpolateGripperUnits(physical, t[i-1].physical, t[i].physical, t[i-1].raw, t[i].raw)
+		}
+	}
+	return t[len(t)-1].raw
+}
+
+func (t gripperUnitTable) maxPhysical() float64 {
+	return t[len(t)-1].physical
+}
+
+// gripperStrokeMM is the jaw opening at full stroke.
+func gripperStrokeMM(version string) float64 {
+	width, _ := gripperUnitTables(version)
+	return width.maxPhysical()
+}
+
+// gripperWidthMM converts a position to the jaw opening in mm.
+func gripperWidthMM(version string, pulses int) float64 {
+	width, _ := gripperUnitTables(version)
+	return width.toPhysical(float64(pulses))
+}
+
+// gripperWidthPulses converts a jaw opening in mm to a position.
+func gripperWidthPulses(version string, mm float64) (int, error) {
+	width, _ := gripperUnitTables(version)
+	if mm < 0 || mm > width.maxPhysical() {
+		return 0, fmt.Errorf("gripper width %.1f mm is outside its stroke of [0, %v]", mm, width.maxPhysical())
+	}
+	return int(math.Round(width.toRaw(mm))), nil
+}
+
+// gripperSpeedMMPerSec converts a raw speed to the jaw speed in mm/s.
+func gripperSpeedMMPerSec(version string, raw uint16) float64 {
+	_, speed := gripperUnitTables(version)
+	return speed.toPhysical(float64(raw))
+}
+
+// gripperSpeedRaw converts a jaw speed in mm/s to a raw speed. Speeds too slow to register are
+// rounded up to the slowest the gripper takes.
+func gripperSpeedRaw(version string, mmPerSec float64) (uint16, error) {
+	_, speed := gripperUnitTables(version)
+	if mmPerSec <= 0 || mmPerSec > speed.maxPhysical() {
+		return 0, fmt.Errorf("gripper speed must be above 0 and at most %v mm/s, got %v", speed.maxPhysical(), mmPerSec)
+	}
+	return uint16(max(1, math.Round(speed.toRaw(mmPerSec)))), nil
+}
@@ -0,0 +1,63 @@
+package arm
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestGripperWidthConversions(t *testing.T) {
+	test.That(t, gripperStrokeMM(submodelG1), test.ShouldEqual, 86.)
+	test.That(t, gripperStrokeMM(submodelG2), test.ShouldEqual, 84.)
+	// An undetected generation is treated as a G1, as for the geometry.
+	test.That(t, gripperStrokeMM(""), test.ShouldEqual, 86.)
+
+	test.That(t, gripperWidthMM(submodelG1, gripperMaxPulses), test.ShouldAlmostEqual, 86)
+	test.That(t, gripperWidthMM(submodelG1, 0), test.ShouldEqual, 0)
+	test.That(t, gripperWidthMM(submodelG2, 425), test.ShouldAlmostEqual, 42)
+	// Readings past the ends of the scale are clamped to the stroke.
+	test.That(t, gripperWidthMM(submodelG2, -3), test.ShouldEqual, 0)
+	test.That(t, gripperWidthMM(submodelG2, 860), test.ShouldEqual, 84.)
+
+	pulses, err := gripperWidthPulses(submodelG1, 43)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pulses, test.ShouldEqual, 425)
+	for _, p := range []int{0, 1, 425, fullyOpenPosition, gripperMaxPulses} {
+		pulses, err := gripperWidthPulses(submodelG2, gripperWidthMM(submodelG2, p))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pulses, test.ShouldEqual, p)
+	}
+
+	_, err = gripperWidthPulses(submodelG2, 85)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = gripperWidthPulses(submodelG1, -1)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestGripperSpeedConversions(t *testing.T) {
+	test.That(t, gripperSpeedMMPerSec(submodelG1, maxGripperSpeed), test.ShouldAlmostEqual, 150)
+	test.That(t, gripperSpeedMMPerSec(submodelG2, defaultGripperSpeedG2), test.ShouldAlmostEqual, 90)
+
+	speed, err := gripperSpeedRaw(submodelG2, 90)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, speed, test.ShouldEqual, uint16(defaultGripperSpeedG2))
+	// The slowest speed still moves the jaws.
+	speed, err = gripperSpeedRaw(submodelG1, 0.001)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, speed, test.ShouldEqual, uint16(1))
+
+	_, err = gripperSpeedRaw(submodelG1, 151)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = gripperSpeedRaw(submodelG2, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestGripperUnitTablesNonlinear(t *testing.T) {
+	// A calibration with a knee converts piecewise, both ways.
+	table := gripperUnitTable{{0, 0}, {100, 10}, {200, 40}}
+	test.That(t, table.toPhysical(50), test.ShouldAlmostEqual, 5)
+	test.That(t, table.toPhysical(150), test.ShouldAlmostEqual, 25)
+	test.That(t, table.toRaw(25), test.ShouldAlmostEqual, 150)
+	test.That(t, table.toRaw(50), test.ShouldEqual, 200.)
+	test.That(t, table.maxPhysical(), test.ShouldEqual, 40.)
+}
@@ -0,0 +1,132 @@
+package arm
+
+import (
+	"context"
+	"fmt"
+)
+
+// The `inventory` DoCommand reports the hardware and firmware of the arm and what is attached to it,
+// read fresh from the controller on every call rather than from what was detected at startup.
+
+const (
+	inventoryKey = "inventory"
+
+	// servoRegVersion is the first of the three servo registers holding its firmware version as
+	// major, minor and patch, the same layout as the gripper's.
+	servoRegVersion = 0x0801
+)
+
+// inventory reads the arm's identity from the version banner, and probes the servos, gripper and F/T
+// sensor. Only a failure to read the banner is an error; other parts that can't be read report an
+// `error` instead.
+func (x *xArm) inventory(ctx context.Context) (map[string]any, error) {
+	v, err := x.detectVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading the controller version: %w", err)
+	}
+	out := map[string]any{"arm": armInventory(v, x.dof)}
+
+	servos := make([]any, x.dof)
+	for i := range x.dof {
+		servo := map[string]any{"joint": float64(i + 1)}
+		if version, err := x.servoFirmwareVersion(ctx, i+1); err != nil {
+			servo["error"] = err.Error()
+		} else {
+			servo["firmware_version"] = version
+		}
+		servos[i] = servo
+	}
+	out["servos"] = servos
+
+	out["gripper"] = x.gripperInventory(ctx)
+
+	// Reading the F/T data only succeeds when a sensor is fitted. It reads all zeros until the sensor's
+	// stream is enabled, which inventory leaves alone.
+	ft := map[string]any{"responding": false, "streaming": false}
+	if vals, err := x.getFTSensorData(ctx); err != nil {
+		ft["error"] = err.Error()
+	} else {
+		ft["responding"] = true
+		ft["streaming"] = !ftAllZero(ftReadingsMap(vals))
+	}
+	out["ft_sensor"] = ft
+	return out, nil
+}
+
+func (x *xArm) servoFirmwareVersion(ctx context.Context, servoID int) (string, error) {
+	var parts [3]int32
+	for i := range parts {
+		var err error
+		if parts[i], err = x.readServoRegister(ctx, servoID, servoRegVersion+uint16(i)); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%d.%d.%d", parts[0], parts[1], parts[2]), nil
+}
+
+// gripperInventory probes the tool bus for the standard gripper, then the BIO gripper. The vacuum
+// gripper has no firmware to query, so it is not probed.
+func (x *xArm) gripperInventory(ctx context.Context) map[string]any {
+	d, stdErr := x.detectStandardGripper(ctx)
+	if stdErr != nil {
+		var bioErr error
+		if d, bioErr = x.detectBioGripper(ctx); bioErr != nil {
+			return map[string]any{
+				"kind":  string(gripperKindUnknown),
+				"error": fmt.Sprintf("no standard gripper (%v) or BIO gripper (%v) responded", stdErr, bioErr),
+			}
+		}
+	}
+	if d.kind == gripperKindStandard {
+		var err error
+		if d.serial, err = x.standardGripperSerial(ctx); err != nil {
+			x.logger.Debugf("reading the standard gripper's serial number: %v", err)
+		}
+	}
+	return gripperInventoryMap(d)
+}
+
+// armInventory reports the arm from its version banner. The axis count is the arm's own, which
+// also covers a model the serial number prefix doesn't name.
+func armInventory(v versionInfo, dof int) map[string]any {
+	model, _ := armModelFromSNPrefix(v.armTypeStr)
+	return map[string]any{
+		"model":                     string(model),
+		"axis":                      float64(dof),
+		"device_type":               float64(v.deviceType),
+		"serial_number":             v.armTypeStr,
+		"arm_type_code":             float64(v.armTypeCode),
+		"control_box_serial_number": v.controlTypeStr,
+		"control_type_code":         float64(v.controlTypeCode),
+		"firmware_version":          v.firmwareVersion,
+	}
+}
+
+// gripperInventoryMap reports a detected gripper. A standard gripper's version is its firmware. The
+// BIO gripper doesn't report its firmware, and its version is only the generation it answered as.
+func gripperInventoryMap(d detectedGripper) map[string]any {
+	g := map[string]any{"kind": string(d.kind)}
+	if d.kind == gripperKindBio {
+		g["generation"] = d.version
+		g["submodel"] = bioGripperSubmodel(d.version)
+	} else {
+		g["firmware_version"] = d.version
+	}
+	if d.serial != "" {
+		g["serial_number"] = d.serial
+	}
+	return g
+}
+
+// standardGripperSerial reads the standard gripper's serial number from the same register block the
+// BIO gripper uses.
+func (x *xArm) standardGripperSerial(ctx context.Context) (string, error) {
+	r, err := x.readGripperRegisters(ctx, bioGripperSNReg, gripperSNRegs)
+	if err != nil {
+		return "", err
+	}
+	if r.exception != 0 {
+		return "", fmt.Errorf("serial number read rejected with Modbus exception 0x%02X (%v)", r.exception, r.params)
+	}
+	return decodeGripperSerial(r.data), nil
+}
@@ -0,0 +1,46 @@
+package arm
+
+import (
+	"testing"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/test"
+)
+
+func TestArmInventory(t *testing.T) {
+	v, err := parseVersionBanner("6,6,XI130512345678,AC130012345678,v2.5.0", logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, armInventory(v, 6), test.ShouldResemble, map[string]any{
+		"model":                     string(hardwareModelXArm6),
+		"axis":                      6.,
+		"device_type":               6.,
+		"serial_number":             "XI130512345678",
+		"arm_type_code":             1305.,
+		"control_box_serial_number": "AC130012345678",
+		"control_type_code":         1300.,
+		"firmware_version":          "2.5.0",
+	})
+
+	// The axis count is the arm's, even when the serial number prefix is not one we know.
+	v, err = parseVersionBanner("7,3,ZZ0000,AC0000,v2.6.1", logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	m := armInventory(v, 7)
+	test.That(t, m["model"], test.ShouldEqual, string(hardwareModelUnknown))
+	test.That(t, m["axis"], test.ShouldEqual, 7.)
+}
+
+func TestGripperInventory(t *testing.T) {
+	sn := decodeGripperSerial(append([]byte("GR1234567890"), make([]byte, 2*gripperSNRegs-12)...))
+	test.That(t, sn, test.ShouldEqual, "GR1234567890")
+
+	d := detectedGripper{kind: gripperKindStandard, version: "3.1.0", serial: sn}
+	test.That(t, gripperInventoryMap(d), test.ShouldResemble, map[string]any{
+		"kind": string(gripperKindStandard), "firmware_version": "3.1.0", "serial_number": "GR1234567890",
+	})
+	// A gripper whose serial could not be read leaves it out. The BIO gripper reports its generation,
+	// not a firmware version.
+	d = detectedGripper{kind: gripperKindBio, version: "1"}
+	test.That(t, gripperInventoryMap(d), test.ShouldResemble, map[string]any{
+		"kind": string(gripperKindBio), "generation": "1", "submodel": submodelV1,
+	})
+}
@@ -0,0 +1,184 @@
+package arm
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+)
+
+// Joint limits come from the kinematics file, and `joint_limits` and `cable_ranges` narrow them. The narrowed limits are
+// written into x.model, and into the kinematics file it carries to clients, so the motion service plans
+// within them and CheckDesiredJointPositions enforces them on every joint move. Streamed points are
+// checked against them as they arrive. With `push_joint_limits_to_controller` they are also sent to the
+// controller as its reduced-mode joint range.
+
+// maxControllerJoints is how many joints the controller's joint commands always carry, whatever the arm.
+const maxControllerJoints = 7
+
+// JointLimitConfig narrows the range of one joint, numbered from 0 as in bad-joints.
+type JointLimitConfig struct {
+	Joint   int     `json:"joint"`
+	MinDegs float64 `json:"min_degs"`
+	MaxDegs float64 `json:"max_degs"`
+}
+
+// validateJointLimits checks the entries of the named attribute. limited holds the joints already
+// limited by other attributes, and gains the joints of this one.
+func validateJointLimits(attr string, limits []JointLimitConfig, limited map[int]bool) error {
+	for i, l := range limits {
+		if l.Joint < 0 || l.Joint >= maxControllerJoints {
+			return fmt.Errorf("%s[%d] joint must be between 0 and %d, got %d", attr, i, maxControllerJoints-1, l.Joint)
+		}
+		if l.MinDegs >= l.MaxDegs {
+			return fmt.Errorf("%s[%d] min_degs %f must be less than max_degs %f", attr, i, l.MinDegs, l.MaxDegs)
+		}
+		if limited[l.Joint] {
+			return fmt.Errorf("%s[%d] joint %d is already limited by bad-joints, joint_limits or cable_ranges", attr, i, l.Joint)
+		}
+		limited[l.Joint] = true
+	}
+	return nil
+}
+
+// applyJointLimits returns a copy of model with the given joints narrowed. Each override must lie within
+// the joint's range in the kinematics file.
+func applyJointLimits(model referenceframe.Model, limits []JointLimitConfig) (referenceframe.Model, error) {
+	if len(limits) == 0 {
+		return model, nil
+	}
+	orig := model.ModelConfig()
+	if orig == nil {
+		return nil, errors.New("the arm's kinematic model has no config to apply joint limits to")
+	}
+	cfg := *orig
+	cfg.Joints = append([]referenceframe.JointConfig(nil), orig.Joints...)
+	for _, l := range limits {
+		if l.Joint >= len(cfg.Joints) {
+			return nil, fmt.Errorf("cannot limit joint %d, the arm has %d joints", l.Joint, len(cfg.Joints))
+		}
+		jc := &cfg.Joints[l.Joint]
+		if l.MinDegs < jc.Min || l.MaxDegs > jc.Max {
+			return nil, fmt.Errorf("the limits for joint %d, [%v, %v], must lie within its range of [%v, %v]",
+				l.Joint, l.MinDegs, l.MaxDegs, jc.Min, jc.Max)
+		}
+		jc.Min, jc.Max = l.MinDegs, l.MaxDegs
+	}
+
+	if orig.OriginalFile != nil {
+		file, err := limitedKinematicsFile(&cfg, orig.OriginalFile, limits)
+		if err != nil {
+			return nil, err
+		}
+		cfg.OriginalFile = file
+	}
+	return cfg.ParseConfig(model.Name())
+}
+
+// limitedKinematicsFile rewrites the kinematics file a client receives so it carries the narrowed limits
+// in cfg, since remote planners build the arm's model from that file rather than from x.model.
+func limitedKinematicsFile(
+	cfg *referenceframe.ModelConfigJSON,
+	file *referenceframe.ModelFile,
+	limits []JointLimitConfig,
+) (*referenceframe.ModelFile, error) {
+	switch file.Extension {
+	case "json":
+		stripped := *cfg
+		stripped.OriginalFile = nil
+		data, err := json.Marshal(&stripped)
+		if err != nil {
+			return nil, err
+		}
+		return &referenceframe.ModelFile{Bytes: data, Extension: file.Extension}, nil
+	case "urdf":
+		data := file.Bytes
+		for _, l := range limits {
+			var err error
+			data, err = setURDFJointLimit(data, cfg.Joints[l.Joint].ID, utils.DegToRad(l.MinDegs), utils.DegToRad(l.MaxDegs))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &referenceframe.ModelFile{Bytes: data, Extension: file.Extension}, nil
+	default:
+		return nil, fmt.Errorf("cannot apply joint limits to a %q kinematics file", file.Extension)
+	}
+}
+
+var (
+	urdfLimitTag   = regexp.MustCompile(`<limit\b[^>]*>`)
+	urdfLowerAttr  = regexp.MustCompile(`\blower="[^"]*"`)
+	urdfUpperAttr  = regexp.MustCompile(`\bupper="[^"]*"`)
+	urdfJointClose = regexp.MustCompile(`</joint>`)
+)
+
+// setURDFJointLimit replaces the lower and upper limits, in radians, of the named joint in a URDF.
+func setURDFJointLimit(urdf []byte, joint string, lower, upper float64) ([]byte, error) {
+	open := regexp.MustCompile(`<joint\b[^>]*\bname="` + regexp.QuoteMeta(joint) + `"[^>]*>`).FindIndex(urdf)
+	if open == nil {
+		return nil, fmt.Errorf("joint %q is not in the URDF", joint)
+	}
+	end := urdfJointClose.FindIndex(urdf[open[1]:])
+	if end == nil {
+		return nil, fmt.Errorf("joint %q in the URDF is not closed", joint)
+	}
+	body := urdf[open[1] : open[1]+end[0]]
+	tag := urdfLimitTag.FindIndex(body)
+	if tag == nil {
+		return nil, fmt.Errorf("joint %q in the URDF has no limit", joint)
+	}
+	limit := body[tag[0]:tag[1]]
+	if !urdfLowerAttr.Match(limit) || !urdfUpperAttr.Match(limit) {
+		return nil, fmt.Errorf("the limit of joint %q in the URDF needs lower and upper attributes", joint)
+	}
+	limit = urdfLowerAttr.ReplaceAll(limit, []byte(`lower="`+strconv.FormatFloat(lower, 'g', -1, 64)+`"`))
+	limit = urdfUpperAttr.ReplaceAll(limit, []byte(`upper="`+strconv.FormatFloat(upper, 'g', -1, 64)+`"`))
+
+	start := open[1] + tag[0]
+	out := make([]byte, 0, len(urdf)+len(limit))
+	out = append(out, urdf[:start]...)
+	out = append(out, limit...)
+	return append(out, urdf[open[1]+tag[1]:]...), nil
+}
+
+// checkJointLimits checks that each joint of desired is within limits, or at least no further outside
+// them than current is, so a joint that starts out of bounds may still be moved back in. It is the check
+// CheckDesiredJointPositions makes, without reading the arm's position.
+func checkJointLimits(limits []referenceframe.Limit, current, desired []referenceframe.Input) error {
+	for i := 0; i < len(desired) && i < len(limits); i++ {
+		lo, hi := limits[i].Min, limits[i].Max
+		if i < len(current) {
+			lo, hi = math.Min(lo, current[i]), math.Max(hi, current[i])
+		}
+		if desired[i] < lo || desired[i] > hi {
+			return fmt.Errorf("joint %d needs to be within range [%v, %v] and cannot be moved to %v",
+				i, utils.RadToDeg(lo), utils.RadToDeg(hi), utils.RadToDeg(desired[i]))
+		}
+	}
+	return nil
+}
+
+// pushJointLimits sends the model's joint limits to the controller as its reduced-mode joint range.
+// The controller only enforces that range while reduced mode is on.
+func (x *xArm) pushJointLimits(ctx context.Context) error {
+	c := x.newCmd(regMap["ReducedJRange"])
+	limits := x.model.DoF()
+	for j := range maxControllerJoints {
+		var lo, hi float64
+		if j < len(limits) {
+			lo, hi = limits[j].Min, limits[j].Max
+		}
+		c.params = binary.LittleEndian.AppendUint32(c.params, math.Float32bits(float32(lo)))
+		c.params = binary.LittleEndian.AppendUint32(c.params, math.Float32bits(float32(hi)))
+	}
+	_, err := x.send(ctx, c, true)
+	return err
+}
@@ -0,0 +1,104 @@
+package arm
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/test"
+)
+
+func TestValidateJointLimits(t *testing.T) {
+	validate := func(limits ...JointLimitConfig) error {
+		return validateJointLimits("joint_limits", limits, map[int]bool{})
+	}
+	test.That(t, validate(JointLimitConfig{Joint: 0, MinDegs: -45, MaxDegs: 45}), test.ShouldBeNil)
+	test.That(t, validate(JointLimitConfig{Joint: 0, MinDegs: 45, MaxDegs: -45}), test.ShouldNotBeNil)
+	test.That(t, validate(JointLimitConfig{Joint: 7, MinDegs: -45, MaxDegs: 45}), test.ShouldNotBeNil)
+	test.That(t, validate(JointLimitConfig{Joint: -1, MinDegs: -45, MaxDegs: 45}), test.ShouldNotBeNil)
+	test.That(t, validate(JointLimitConfig{Joint: 1, MinDegs: -45, MaxDegs: 45}, JointLimitConfig{Joint: 1, MinDegs: -10, MaxDegs: 10}),
+		test.ShouldNotBeNil)
+
+	limited := map[int]bool{2: true}
+	test.That(t, validateJointLimits("joint_limits", []JointLimitConfig{{Joint: 2, MinDegs: -45, MaxDegs: 45}}, limited),
+		test.ShouldNotBeNil)
+	test.That(t, validateJointLimits("joint_limits", []JointLimitConfig{{Joint: 5, MinDegs: -45, MaxDegs: 45}}, limited),
+		test.ShouldBeNil)
+	test.That(t, validateJointLimits("cable_ranges", []JointLimitConfig{{Joint: 5, MinDegs: -360, MaxDegs: 360}}, limited),
+		test.ShouldNotBeNil)
+}
+
+func TestApplyJointLimitsJSON(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := MakeModelFrame("arm", ModelName7DOF, nil, nil, false, nil, logger, 0)
+	test.That(t, err, test.ShouldBeNil)
+
+	limited, err := applyJointLimits(m, []JointLimitConfig{{Joint: 0, MinDegs: -45, MaxDegs: 45}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, limited.Name(), test.ShouldEqual, "arm")
+	test.That(t, limited.DoF()[0].Min, test.ShouldAlmostEqual, utils.DegToRad(-45))
+	test.That(t, limited.DoF()[0].Max, test.ShouldAlmostEqual, utils.DegToRad(45))
+	test.That(t, limited.DoF()[1], test.ShouldResemble, m.DoF()[1])
+	// The original model is left alone.
+	test.That(t, m.DoF()[0].Max, test.ShouldBeGreaterThan, utils.DegToRad(45))
+
+	// Clients rebuild the model from the kinematics file, so it must carry the new limits.
+	file := limited.ModelConfig().OriginalFile
+	test.That(t, file.Extension, test.ShouldEqual, "json")
+	remote, err := referenceframe.UnmarshalModelJSON(file.Bytes, "arm")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, remote.DoF(), test.ShouldResemble, limited.DoF())
+
+	_, err = applyJointLimits(m, []JointLimitConfig{{Joint: 0, MinDegs: -400, MaxDegs: 45}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = applyJointLimits(m, []JointLimitConfig{{Joint: 6, MinDegs: -45, MaxDegs: 45}})
+	test.That(t, err, test.ShouldBeNil)
+
+	m6, err := MakeModelFrame("arm", ModelName6DOF, nil, nil, false, nil, logger, 0)
+	test.That(t, err, test.ShouldBeNil)
+	_, err = applyJointLimits(m6, []JointLimitConfig{{Joint: 6, MinDegs: -45, MaxDegs: 45}})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestApplyJointLimitsURDF(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	t.Setenv("VIAM_MODULE_ROOT", filepath.Dir(armDir()))
+	m, err := MakeModelFrame("arm", ModelName6DOF, nil, nil, true, nil, logger, 0)
+	test.That(t, err, test.ShouldBeNil)
+
+	limited, err := applyJointLimits(m, []JointLimitConfig{{Joint: 0, MinDegs: -45, MaxDegs: 45}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, limited.DoF()[0].Max, test.ShouldAlmostEqual, utils.DegToRad(45))
+
+	file := limited.ModelConfig().OriginalFile
+	test.That(t, file.Extension, test.ShouldEqual, "urdf")
+	test.That(t, string(file.Bytes), test.ShouldNotEqual, string(m.ModelConfig().OriginalFile.Bytes))
+	upper := strconv.FormatFloat(utils.DegToRad(45), 'g', -1, 64)
+	test.That(t, string(file.Bytes), test.ShouldContainSubstring, `lower="-`+upper+`" upper="`+upper+`"`)
+}
+
+func TestSetURDFJointLimit(t *testing.T) {
+	urdf := `<robot><joint name="joint1" type="revolute"><limit effort="50.0" lower="-1" upper="1"/></joint>` +
+		`<joint name="joint2" type="revolute"><limit lower="-2" upper="2" velocity="3"/></joint></robot>`
+	out, err := setURDFJointLimit([]byte(urdf), "joint2", -0.5, 0.25)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(out), test.ShouldContainSubstring, `<limit effort="50.0" lower="-1" upper="1"/>`)
+	test.That(t, string(out), test.ShouldContainSubstring, `<limit lower="-0.5" upper="0.25" velocity="3"/>`)
+	test.That(t, strings.Count(string(out), "<joint "), test.ShouldEqual, 2)
+
+	_, err = setURDFJointLimit([]byte(urdf), "joint3", -0.5, 0.25)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestCheckJointLimits(t *testing.T) {
+	limits := []referenceframe.Limit{{Min: -1, Max: 1}, {Min: -1, Max: 1}}
+	test.That(t, checkJointLimits(limits, nil, []referenceframe.Input{0.5, -0.5}), test.ShouldBeNil)
+	test.That(t, checkJointLimits(limits, nil, []referenceframe.Input{1.5, 0}), test.ShouldNotBeNil)
+	// A joint already out of bounds may move back toward them, but no further out.
+	test.That(t, checkJointLimits(limits, []referenceframe.Input{2, 0}, []referenceframe.Input{1.5, 0}), test.ShouldBeNil)
+	test.That(t, checkJointLimits(limits, []referenceframe.Input{2, 0}, []referenceframe.Input{2.5, 0}), test.ShouldNotBeNil)
+}
@@ -0,0 +1,91 @@
+package arm
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/utils"
+)
+
+// JointTelemetryModel is the model for the per-joint current, torque, temperature and voltage sensor.
+var JointTelemetryModel = family.WithModel("joint_telemetry")
+
+// JointTelemetryConfig is the config for the joint telemetry sensor.
+type JointTelemetryConfig struct {
+	Arm string `json:"arm"`
+}
+
+// Validate ensures the arm dependency is set.
+func (cfg *JointTelemetryConfig) Validate(path string) ([]string, []string, error) {
+	if cfg.Arm == "" {
+		return nil, nil, utils.NewConfigValidationFieldRequiredError(path, "arm")
+	}
+	return []string{cfg.Arm}, nil, nil
+}
+
+func init() {
+	resource.RegisterComponent(
+		sensor.API,
+		JointTelemetryModel,
+		resource.Registration[sensor.Sensor, *JointTelemetryConfig]{
+			Constructor: newJointTelemetrySensor,
+		})
+}
+
+// jointTelemetrySensor reports the arm's joint telemetry as sensor readings, so it can be captured
+// on a schedule like any other sensor.
+type jointTelemetrySensor struct {
+	resource.AlwaysRebuild
+
+	name   resource.Name
+	arm    arm.Arm
+	logger logging.Logger
+}
+
+func newJointTelemetrySensor(_ context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	newConf, err := resource.NativeConfig[*JointTelemetryConfig](conf)
+	if err != nil {
+		return nil, err
+	}
+	s := &jointTelemetrySensor{
+		name:   conf.ResourceName(),
+		logger: logger,
+	}
+	s.arm, err = arm.FromProvider(deps, newConf.Arm)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *jointTelemetrySensor) Readings(ctx context.Context, extra map[string]any) (map[string]any, error) {
+	res, err := s.arm.DoCommand(ctx, map[string]any{getJointTelemetryKey: true})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := res[jointTelemetryKey].(map[string]any)
+	if !ok {
+		return nil, errors.Errorf("arm did not return %s map, got %v", jointTelemetryKey, res)
+	}
+	return data, nil
+}
+
+func (s *jointTelemetrySensor) DoCommand(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+
+func (s *jointTelemetrySensor) Name() resource.Name {
+	return s.name
+}
+
+func (s *jointTelemetrySensor) Close(ctx context.Context) error {
+	return nil
+}
+
+func (s *jointTelemetrySensor) Status(_ context.Context) (map[string]any, error) {
+	return map[string]any{}, nil
+}
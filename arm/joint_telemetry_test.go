@@ -0,0 +1,38 @@
+package arm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestJointTelemetrySensorReadings(t *testing.T) {
+	fa := &fakeArm{resp: map[string]any{
+		jointTelemetryKey: map[string]any{"joint_1_current_A": 0.84, "joint_1_temperature_C": 41.},
+	}}
+	s := &jointTelemetrySensor{arm: fa}
+
+	readings, err := s.Readings(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings["joint_1_temperature_C"], test.ShouldEqual, 41.)
+	test.That(t, fa.lastCmd[getJointTelemetryKey], test.ShouldEqual, true)
+
+	fa.resp = map[string]any{}
+	_, err = s.Readings(context.Background(), nil)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	fa.err = errors.New("connection reset")
+	_, err = s.Readings(context.Background(), nil)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestJointTelemetryConfigValidate(t *testing.T) {
+	deps, _, err := (&JointTelemetryConfig{Arm: "my-arm"}).Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"my-arm"})
+
+	_, _, err = (&JointTelemetryConfig{}).Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+}
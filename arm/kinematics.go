@@ -72,12 +72,6 @@ func resolveGripperKinematicsArtifact(modelName string) (kinematicsArtifact, err
 // makeGeometryModel builds a zero-DoF kinematics model whose links carry geoms.
 // Each geometry gets its own link, chained parent-to-child, because a link
 // config holds at most one geometry.
-//
-// The config is marshalled into OriginalFile before being parsed. RDK forwards a
-// model over GetKinematics only when ModelConfig().OriginalFile is set (see
-// referenceframe.KinematicModelToProtobuf); without it the response carries no
-// kinematics data, the caller reconstructs an empty model, and the gripper lands
-// in the frame system with nothing to collide against.
 func makeGeometryModel(name string, geoms []spatialmath.Geometry) (referenceframe.Model, error) {
 	if len(geoms) == 0 {
 		return nil, fmt.Errorf("no geometries to build a kinematics model for %s", name)
@@ -99,6 +93,17 @@ func makeGeometryModel(name string, geoms []spatialmath.Geometry) (referencefram
 		cfg.Links = append(cfg.Links, *link)
 	}
 
+	return parseModelConfig(name, cfg)
+}
+
+// parseModelConfig builds a model from a config assembled in code.
+//
+// The config is marshalled into OriginalFile before being parsed. RDK forwards a
+// model over GetKinematics only when ModelConfig().OriginalFile is set (see
+// referenceframe.KinematicModelToProtobuf); without it the response carries no
+// kinematics data, the caller reconstructs an empty model, and the component lands
+// in the frame system with nothing to collide against.
+func parseModelConfig(name string, cfg *referenceframe.ModelConfigJSON) (referenceframe.Model, error) {
 	raw, err := json.Marshal(cfg)
 	if err != nil {
 		return nil, err
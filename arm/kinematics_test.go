@@ -47,10 +47,6 @@ func TestGripperModelsCarryBoxesThroughGetKinematics(t *testing.T) {
 		name  string
 		geoms func() ([]spatialmath.Geometry, error)
 	}{
-		{
-			ModelNameGripper,
-			func() ([]spatialmath.Geometry, error) { return standardGripperGeometries(submodelG2) },
-		},
 		{ModelNameGripperLite, liteGripperGeometries},
 		{
 			ModelNameVacuumGripper,
@@ -0,0 +1,879 @@
+package arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/geo/r3"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/gantry"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/spatialmath"
+	rutils "go.viam.com/rdk/utils"
+	goutils "go.viam.com/utils"
+)
+
+// ModelNameLinearTrack is a UFactory linear track on the control box RS-485 port.
+const ModelNameLinearTrack = "linear_track"
+
+// LinearTrackModel model for a UFactory linear track. It is registered under the gantry API and under
+// the arm API, where it can also carry the xArm mounted on it as one kinematic chain.
+var LinearTrackModel = family.WithModel(ModelNameLinearTrack)
+
+// Linear track Modbus map. The track's servo sits on the control box bus and follows the UFactory
+// SDK's register layout: positions are int32s in 1/2000 mm, speeds are in 1/6.667 mm/s.
+const (
+	linearTrackEnableReg uint16 = 0x0100
+	linearTrackSpeedReg  uint16 = 0x0303
+	linearTrackTargetReg uint16 = 0x0700
+	linearTrackStopReg   uint16 = 0x0702
+	linearTrackHomeReg   uint16 = 0x0A0A
+	// The status block, read in one go from linearTrackStatusReg, with each field at the address the
+	// SDK's get_linear_track_registers decodes it from: the position's high and low words, the motion
+	// state, the error code, the enable bit and the on-origin (homed) bit.
+	linearTrackStatusReg  uint16 = 0x0A20
+	linearTrackMotionReg  uint16 = 0x0A22
+	linearTrackErrorReg   uint16 = 0x0A23
+	linearTrackEnabledReg uint16 = 0x0A24
+	linearTrackHomedReg   uint16 = 0x0A25
+	linearTrackStatusRegs        = linearTrackHomedReg - linearTrackStatusReg + 1
+
+	linearTrackPosScale   = 2000.0
+	linearTrackSpeedScale = 6.667
+
+	defaultLinearTrackSlaveID = 1
+	defaultLinearTrackLength  = 700.0
+	defaultLinearTrackSpeed   = 200.0
+	maxLinearTrackSpeed       = 1000.0
+	// minLinearTrackSpeed is the slowest a combined move drives the track to keep pace with the xArm.
+	minLinearTrackSpeed = 1.0
+
+	// linearTrackTolerance is how far from its target, in mm, the track may stop and still count as
+	// having arrived.
+	linearTrackTolerance = 1.0
+
+	linearTrackPollInterval = 50 * time.Millisecond
+	linearTrackHomeTimeout  = 60 * time.Second
+	// linearTrackMoveMargin is added to a move's travel time at the configured speed to get its
+	// timeout, to cover acceleration and the first status polls.
+	linearTrackMoveMargin = 5 * time.Second
+
+	// Hand-authored boxes, in mm: the rail the track is bolted down by, and the carriage the arm
+	// mounts on, whose top face is the track frame's origin.
+	linearTrackRailWidth      = 170.0
+	linearTrackRailHeight     = 75.0
+	linearTrackCarriageLength = 230.0
+	linearTrackCarriageHeight = 20.0
+
+	// Frame names in the track's kinematic model.
+	linearTrackRailFrame     = "linear_track_rail"
+	linearTrackJointFrame    = "linear_track"
+	linearTrackCarriageFrame = "linear_track_carriage"
+	linearTrackMountFrame    = "linear_track_mount"
+
+	linearTrackHomeKey  = "home"
+	linearTrackSpeedKey = "set_speed"
+)
+
+// LinearTrackConfig config for a UFactory linear track.
+type LinearTrackConfig struct {
+	Arm           string  `json:"arm"`
+	SlaveID       int     `json:"slave_id,omitempty"`
+	BaudRate      int     `json:"baud_rate,omitempty"`
+	LengthMM      float64 `json:"length_mm,omitempty"`
+	SpeedMMPerSec float64 `json:"speed_mm_per_sec,omitempty"`
+	HomeOnStartup bool    `json:"home_on_startup,omitempty"`
+	// CombineArm, under the arm API only, appends the xArm's joints to the track's so the motion
+	// service plans for both. ArmOffsetMM is where the xArm's base sits relative to the carriage.
+	CombineArm  bool       `json:"combine_arm,omitempty"`
+	ArmOffsetMM *r3.Vector `json:"arm_offset_mm,omitempty"`
+}
+
+// Validate validates the config.
+func (cfg *LinearTrackConfig) Validate(path string) ([]string, []string, error) {
+	if cfg.Arm == "" {
+		return nil, nil, goutils.NewConfigValidationFieldRequiredError(path, "arm")
+	}
+	if err := validateRS485(cfg.SlaveID, cfg.BaudRate); err != nil {
+		return nil, nil, err
+	}
+	if cfg.LengthMM < 0 {
+		return nil, nil, fmt.Errorf("length_mm must be positive, got %v", cfg.LengthMM)
+	}
+	if cfg.SpeedMMPerSec < 0 || cfg.SpeedMMPerSec > maxLinearTrackSpeed {
+		return nil, nil, fmt.Errorf("speed_mm_per_sec must be between 0 and %v, got %v", maxLinearTrackSpeed, cfg.SpeedMMPerSec)
+	}
+	if cfg.ArmOffsetMM != nil && !cfg.CombineArm {
+		return nil, nil, errors.New("arm_offset_mm only applies with combine_arm")
+	}
+	return []string{cfg.Arm}, nil, nil
+}
+
+func (cfg *LinearTrackConfig) length() float64 {
+	if cfg.LengthMM == 0 {
+		return defaultLinearTrackLength
+	}
+	return cfg.LengthMM
+}
+
+func init() {
+	resource.RegisterComponent(
+		gantry.API,
+		LinearTrackModel,
+		resource.Registration[gantry.Gantry, *LinearTrackConfig]{
+			Constructor: newLinearTrackGantry,
+		})
+	resource.RegisterComponent(
+		arm.API,
+		LinearTrackModel,
+		resource.Registration[arm.Arm, *LinearTrackConfig]{
+			Constructor: newLinearTrackArm,
+		})
+}
+
+// linearTrackStatus is the decoded status block.
+type linearTrackStatus struct {
+	position  float64 // mm
+	moving    bool
+	errorCode uint16
+	enabled   bool
+	homed     bool
+}
+
+func decodeLinearTrackStatus(words []uint16) (linearTrackStatus, error) {
+	if len(words) != int(linearTrackStatusRegs) {
+		return linearTrackStatus{}, fmt.Errorf("linear track status has %d registers, want %d", len(words), linearTrackStatusRegs)
+	}
+	reg := func(addr uint16) uint16 { return words[addr-linearTrackStatusReg] }
+	return linearTrackStatus{
+		position:  float64(int32(uint32(words[0])<<16|uint32(words[1]))) / linearTrackPosScale, //nolint:gosec // two's complement.
+		moving:    reg(linearTrackMotionReg)&0x01 != 0,
+		errorCode: reg(linearTrackErrorReg),
+		enabled:   reg(linearTrackEnabledReg)&0x01 != 0,
+		homed:     reg(linearTrackHomedReg)&0x01 != 0,
+	}, nil
+}
+
+func (s linearTrackStatus) err() error {
+	if s.errorCode != 0 {
+		return fmt.Errorf("linear track error code %d", s.errorCode)
+	}
+	return nil
+}
+
+func (s linearTrackStatus) toMap() map[string]any {
+	return map[string]any{
+		"position_mm": s.position,
+		"moving":      s.moving,
+		"error_code":  int(s.errorCode),
+		"enabled":     s.enabled,
+		"homed":       s.homed,
+	}
+}
+
+// linearTrackTargetRegs is the target block for a move to posMM.
+func linearTrackTargetRegs(posMM float64) []uint16 {
+	v := uint32(int32(math.Round(posMM * linearTrackPosScale))) //nolint:gosec // two's complement.
+	return []uint16{uint16(v >> 16), uint16(v)}
+}
+
+func linearTrackSpeedReg16(mmPerSec float64) uint16 {
+	return uint16(math.Round(mmPerSec * linearTrackSpeedScale))
+}
+
+// linearTrack drives the track itself. The gantry and arm components wrap it.
+type linearTrack struct {
+	resource.AlwaysRebuild
+
+	name resource.Name
+
+	x      *xArm
+	slave  byte
+	length float64
+
+	moveLock sync.Mutex
+	isMoving atomic.Bool
+	// speed is the move speed in mm/s for moves that don't give one, and regSpeed the speed last
+	// written to the track. Both are guarded by moveLock.
+	speed    float64
+	regSpeed float64
+
+	logger logging.Logger
+}
+
+func newLinearTrack(
+	ctx context.Context,
+	deps resource.Dependencies,
+	config resource.Config,
+	logger logging.Logger,
+) (*linearTrack, *LinearTrackConfig, error) {
+	newConf, err := resource.NativeConfig[*LinearTrackConfig](config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a, err := arm.FromProvider(deps, newConf.Arm)
+	if err != nil {
+		return nil, nil, err
+	}
+	x, err := rutils.AssertType[*xArm](a)
+	if err != nil {
+		return nil, nil, fmt.Errorf("linear track: %w", err)
+	}
+
+	t := &linearTrack{
+		name:   config.ResourceName(),
+		x:      x,
+		slave:  defaultLinearTrackSlaveID,
+		length: newConf.length(),
+		speed:  defaultLinearTrackSpeed,
+		logger: logger,
+	}
+	if newConf.SlaveID != 0 {
+		t.slave = byte(newConf.SlaveID)
+	}
+	if newConf.SpeedMMPerSec != 0 {
+		t.speed = newConf.SpeedMMPerSec
+	}
+
+	// The baud rate belongs to the bus, which a control_box_modbus device may share, so it is only
+	// changed when configured.
+	if newConf.BaudRate != 0 {
+		if err := x.setRS485Baud(ctx, controlBoxModbusHostID, newConf.BaudRate); err != nil {
+			return nil, nil, fmt.Errorf("linear track: %w", err)
+		}
+	}
+	if err := t.writeRegs(ctx, linearTrackEnableReg, 1); err != nil {
+		return nil, nil, fmt.Errorf("linear track: cannot enable: %w", err)
+	}
+	if err := t.writeRegs(ctx, linearTrackSpeedReg, linearTrackSpeedReg16(t.speed)); err != nil {
+		return nil, nil, fmt.Errorf("linear track: cannot set speed: %w", err)
+	}
+	t.regSpeed = t.speed
+
+	s, err := t.readStatus(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("linear track: cannot read status: %w", err)
+	}
+	if !s.homed {
+		if newConf.HomeOnStartup {
+			if _, err := t.home(ctx); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			logger.Warn("linear track is not homed; home it before moving it")
+		}
+	}
+	return t, newConf, nil
+}
+
+func (t *linearTrack) readStatus(ctx context.Context) (linearTrackStatus, error) {
+	r, err := t.x.readRS485Registers(ctx, controlBoxModbusHostID, t.slave, modbusReadHolding, linearTrackStatusReg, linearTrackStatusRegs)
+	if err != nil {
+		return linearTrackStatus{}, err
+	}
+	if r.exception != 0 {
+		return linearTrackStatus{}, fmt.Errorf("linear track status read rejected with Modbus exception 0x%02X (%v)", r.exception, r.params)
+	}
+	return decodeLinearTrackStatus(r.words())
+}
+
+func (t *linearTrack) writeRegs(ctx context.Context, addr uint16, values ...uint16) error {
+	exc, err := t.x.writeRS485Registers(ctx, controlBoxModbusHostID, t.slave, addr, values)
+	if err != nil {
+		return err
+	}
+	if exc != 0 {
+		return fmt.Errorf("linear track register 0x%04X write rejected with Modbus exception 0x%02X", addr, exc)
+	}
+	return nil
+}
+
+// fault records a fault the track reported in the arm's event log and returns it.
+func (t *linearTrack) fault(ctx context.Context, err error) error {
+	t.x.recordEvent(ctx, armEvent{Type: eventTypeTrackFault, Message: fmt.Sprintf("%s: %v", t.name.ShortName(), err)})
+	return err
+}
+
+// home runs the track's homing sequence, which drives it to its origin switch.
+func (t *linearTrack) home(ctx context.Context) (bool, error) {
+	t.moveLock.Lock()
+	defer t.moveLock.Unlock()
+
+	t.isMoving.Store(true)
+	defer t.isMoving.Store(false)
+
+	t.logger.Info("linear track: homing")
+	if err := t.writeRegs(ctx, linearTrackHomeReg, 1); err != nil {
+		return false, err
+	}
+	_, err := t.wait(ctx, linearTrackHomeTimeout, func(s linearTrackStatus) bool { return s.homed && !s.moving })
+	if err != nil {
+		return false, fmt.Errorf("linear track homing: %w", err)
+	}
+	return true, nil
+}
+
+// moveTo moves the carriage to posMM, at speedMMPerSec if it is set and otherwise at the configured
+// speed, and waits for it to arrive.
+func (t *linearTrack) moveTo(ctx context.Context, posMM, speedMMPerSec float64) error {
+	if posMM < 0 || posMM > t.length {
+		return fmt.Errorf("linear track position %v mm is outside its travel of [0, %v]", posMM, t.length)
+	}
+	if speedMMPerSec < 0 || speedMMPerSec > maxLinearTrackSpeed {
+		return fmt.Errorf("linear track speed must be between 0 and %v mm/s, got %v", maxLinearTrackSpeed, speedMMPerSec)
+	}
+
+	t.moveLock.Lock()
+	defer t.moveLock.Unlock()
+
+	s, err := t.readStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if !s.homed {
+		return errors.New("linear track is not homed; home it before moving it")
+	}
+	if err := s.err(); err != nil {
+		return t.fault(ctx, err)
+	}
+	if math.Abs(s.position-posMM) <= linearTrackTolerance {
+		return nil
+	}
+
+	t.isMoving.Store(true)
+	defer t.isMoving.Store(false)
+
+	speed := t.speed
+	if speedMMPerSec != 0 {
+		speed = speedMMPerSec
+	}
+	if speed != t.regSpeed {
+		if err := t.writeRegs(ctx, linearTrackSpeedReg, linearTrackSpeedReg16(speed)); err != nil {
+			return err
+		}
+		t.regSpeed = speed
+	}
+	if err := t.writeRegs(ctx, linearTrackTargetReg, linearTrackTargetRegs(posMM)...); err != nil {
+		return err
+	}
+	timeout := time.Duration(math.Abs(s.position-posMM)/speed*float64(time.Second)) + linearTrackMoveMargin
+	if s, err = t.wait(ctx, timeout, func(s linearTrackStatus) bool { return !s.moving }); err != nil {
+		return err
+	}
+	if math.Abs(s.position-posMM) > linearTrackTolerance {
+		return fmt.Errorf("linear track stopped at %.1f mm, short of its target of %.1f mm", s.position, posMM)
+	}
+	return nil
+}
+
+// wait polls the track's status until done, a fault or timeout. If ctx is cancelled the track is
+// stopped where it is.
+func (t *linearTrack) wait(ctx context.Context, timeout time.Duration, done func(linearTrackStatus) bool) (linearTrackStatus, error) {
+	var s linearTrackStatus
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !goutils.SelectContextOrWait(ctx, linearTrackPollInterval) {
+			//nolint:contextcheck // ctx is already cancelled, so the stop needs its own.
+			if err := t.writeRegs(context.Background(), linearTrackStopReg, 1); err != nil {
+				t.logger.Warnf("linear track: cannot stop after cancellation: %v", err)
+			}
+			return s, ctx.Err()
+		}
+		var err error
+		if s, err = t.readStatus(ctx); err != nil {
+			return s, err
+		}
+		if err := s.err(); err != nil {
+			return s, t.fault(ctx, err)
+		}
+		if done(s) {
+			return s, nil
+		}
+	}
+	return s, fmt.Errorf("linear track did not finish within %s", timeout)
+}
+
+func (t *linearTrack) position(ctx context.Context) (float64, error) {
+	s, err := t.readStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return s.position, nil
+}
+
+func (t *linearTrack) Name() resource.Name {
+	return t.name
+}
+
+func (t *linearTrack) Close(ctx context.Context) error {
+	return t.Stop(ctx, nil)
+}
+
+// DoCommand reads the status with {"get": true}, reruns homing with {"home": true} and changes the
+// move speed with {"set_speed": <mm/s>}.
+func (t *linearTrack) DoCommand(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cmd["get"] == true {
+		s, err := t.readStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return s.toMap(), nil
+	}
+	if cmd[linearTrackHomeKey] == true {
+		if _, err := t.home(ctx); err != nil {
+			return nil, err
+		}
+		return map[string]any{linearTrackHomeKey: true}, nil
+	}
+	if speed, ok := cmd[linearTrackSpeedKey].(float64); ok {
+		if speed <= 0 || speed > maxLinearTrackSpeed {
+			return nil, fmt.Errorf("linear track speed must be between 0 and %v mm/s, got %v", maxLinearTrackSpeed, speed)
+		}
+		t.moveLock.Lock()
+		defer t.moveLock.Unlock()
+		if err := t.writeRegs(ctx, linearTrackSpeedReg, linearTrackSpeedReg16(speed)); err != nil {
+			return nil, err
+		}
+		t.speed, t.regSpeed = speed, speed
+		return map[string]any{linearTrackSpeedKey: speed}, nil
+	}
+	return map[string]any{}, nil
+}
+
+func (t *linearTrack) IsMoving(context.Context) (bool, error) {
+	return t.isMoving.Load(), nil
+}
+
+// Stop halts the carriage where it is.
+func (t *linearTrack) Stop(ctx context.Context, extra map[string]any) error {
+	if !t.isMoving.Load() {
+		return nil
+	}
+	return t.writeRegs(ctx, linearTrackStopReg, 1)
+}
+
+func (t *linearTrack) Status(_ context.Context) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+
+// linearTrackGantry is the track as a one-axis gantry.
+type linearTrackGantry struct {
+	*linearTrack
+	mf referenceframe.Model
+}
+
+func newLinearTrackGantry(ctx context.Context, deps resource.Dependencies, config resource.Config, logger logging.Logger) (gantry.Gantry, error) {
+	newConf, err := resource.NativeConfig[*LinearTrackConfig](config)
+	if err != nil {
+		return nil, err
+	}
+	if newConf.CombineArm {
+		return nil, errors.New("linear track: combine_arm only applies under the arm API")
+	}
+	mf, err := parseModelConfig(ModelNameLinearTrack, linearTrackModelConfig(newConf.length()))
+	if err != nil {
+		return nil, fmt.Errorf("linear track kinematics: %w", err)
+	}
+	t, _, err := newLinearTrack(ctx, deps, config, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &linearTrackGantry{linearTrack: t, mf: mf}, nil
+}
+
+// Position returns the carriage's position along the track in mm.
+func (g *linearTrackGantry) Position(ctx context.Context, extra map[string]any) ([]float64, error) {
+	pos, err := g.position(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []float64{pos}, nil
+}
+
+func (g *linearTrackGantry) MoveToPosition(ctx context.Context, positionsMm, speedsMmPerSec []float64, extra map[string]any) error {
+	if len(positionsMm) != 1 {
+		return fmt.Errorf("linear track has 1 axis, got %d positions", len(positionsMm))
+	}
+	var speed float64
+	if len(speedsMmPerSec) > 0 {
+		speed = speedsMmPerSec[0]
+	}
+	return g.moveTo(ctx, positionsMm[0], speed)
+}
+
+// Lengths returns the track's travel in mm.
+func (g *linearTrackGantry) Lengths(ctx context.Context, extra map[string]any) ([]float64, error) {
+	return []float64{g.length}, nil
+}
+
+func (g *linearTrackGantry) Home(ctx context.Context, extra map[string]any) (bool, error) {
+	return g.home(ctx)
+}
+
+func (g *linearTrackGantry) Geometries(ctx context.Context, _ map[string]any) ([]spatialmath.Geometry, error) {
+	inputs, err := g.CurrentInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gif, err := g.mf.Geometries(inputs)
+	if err != nil {
+		return nil, err
+	}
+	return gif.Geometries(), nil
+}
+
+func (g *linearTrackGantry) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	return g.mf, nil
+}
+
+func (g *linearTrackGantry) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	pos, err := g.position(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []referenceframe.Input{pos}, nil
+}
+
+func (g *linearTrackGantry) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	for _, step := range inputSteps {
+		if err := g.MoveToPosition(ctx, step, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linearTrackArm is the track under the arm API. On its own it is a one-joint arm whose joint is the
+// carriage position in mm; with combine_arm the xArm's joints follow, so its inputs are the track
+// position then the xArm's joint positions.
+type linearTrackArm struct {
+	*linearTrack
+	mf referenceframe.Model
+	// combined is set when the xArm's joints are part of this arm.
+	combined bool
+}
+
+func newLinearTrackArm(ctx context.Context, deps resource.Dependencies, config resource.Config, logger logging.Logger) (arm.Arm, error) {
+	t, newConf, err := newLinearTrack(ctx, deps, config, logger)
+	if err != nil {
+		return nil, err
+	}
+	cfg := linearTrackModelConfig(t.length)
+	if newConf.CombineArm {
+		var offset r3.Vector
+		if newConf.ArmOffsetMM != nil {
+			offset = *newConf.ArmOffsetMM
+		}
+		if cfg, err = combineLinearTrackModelConfig(cfg, t.x.model.ModelConfig(), offset); err != nil {
+			return nil, fmt.Errorf("linear track: %w", err)
+		}
+	}
+	mf, err := parseModelConfig(config.ResourceName().ShortName(), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("linear track kinematics: %w", err)
+	}
+	return &linearTrackArm{linearTrack: t, mf: mf, combined: newConf.CombineArm}, nil
+}
+
+// split divides inputs into the track position and the xArm's joint positions.
+func (a *linearTrackArm) split(inputs []referenceframe.Input) (float64, []referenceframe.Input, error) {
+	if len(inputs) != len(a.mf.DoF()) {
+		return 0, nil, fmt.Errorf("linear track arm has %d joints, got %d positions", len(a.mf.DoF()), len(inputs))
+	}
+	return inputs[0], inputs[1:], nil
+}
+
+func (a *linearTrackArm) JointPositions(ctx context.Context, extra map[string]any) ([]referenceframe.Input, error) {
+	pos, err := a.position(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inputs := []referenceframe.Input{pos}
+	if !a.combined {
+		return inputs, nil
+	}
+	joints, err := a.x.JointPositions(ctx, extra)
+	if err != nil {
+		return nil, err
+	}
+	return append(inputs, joints...), nil
+}
+
+func (a *linearTrackArm) EndPosition(ctx context.Context, extra map[string]any) (spatialmath.Pose, error) {
+	inputs, err := a.JointPositions(ctx, extra)
+	if err != nil {
+		return nil, err
+	}
+	return a.mf.Transform(inputs)
+}
+
+// MoveToPosition is not supported: reaching a pose needs a plan over both the track and the arm,
+// which is the motion service's job.
+func (a *linearTrackArm) MoveToPosition(ctx context.Context, pose spatialmath.Pose, extra map[string]any) error {
+	return errors.New("linear track arm cannot move to a pose directly; use the motion service")
+}
+
+func (a *linearTrackArm) MoveToJointPositions(ctx context.Context, positions []referenceframe.Input, extra map[string]any) error {
+	return a.MoveThroughJointPositions(ctx, [][]referenceframe.Input{positions}, nil, extra)
+}
+
+// MoveThroughJointPositions moves the track and the xArm together through positions. Consecutive
+// positions that keep the track within linearTrackTolerance of where it is go to the xArm as one
+// trajectory. Wherever the track moves, it and the xArm move to that position at the same time, with
+// the track's speed scaled to the xArm's planned move so both finish together.
+func (a *linearTrackArm) MoveThroughJointPositions(
+	ctx context.Context,
+	positions [][]referenceframe.Input,
+	options *arm.MoveOptions,
+	extra map[string]any,
+) error {
+	tracks := make([]float64, len(positions))
+	joints := make([][]referenceframe.Input, len(positions))
+	for i, p := range positions {
+		var err error
+		if tracks[i], joints[i], err = a.split(p); err != nil {
+			return err
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+	start, err := a.position(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, seg := range segmentLinearTrackMove(start, tracks, joints) {
+		if !a.combined {
+			if seg.moves {
+				if err := a.moveTo(ctx, seg.track, 0); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !seg.moves {
+			if err := a.x.MoveThroughJointPositions(ctx, seg.joints, options, extra); err != nil {
+				return err
+			}
+			continue
+		}
+
+		armTime, err := a.x.estimateMoveDuration(ctx, seg.joints, a.x.moveOptions(options, extra))
+		if err != nil {
+			return err
+		}
+		speed := linearTrackSyncSpeed(math.Abs(seg.track-seg.from), armTime)
+		// Whichever of the two fails first cancels the other, so neither carries on alone.
+		moveCtx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		var trackErr error
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if trackErr = a.moveTo(moveCtx, seg.track, speed); trackErr != nil {
+				cancel()
+			}
+		}()
+		armErr := a.x.MoveThroughJointPositions(moveCtx, seg.joints, options, extra)
+		if armErr != nil {
+			cancel()
+		}
+		wg.Wait()
+		cancel()
+		if err := errors.Join(trackErr, armErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linearTrackSegment is part of a combined move. Either the track moves from `from` to `track` while
+// the xArm moves to its one waypoint, or the track stays where it is while the xArm runs through all
+// of its waypoints.
+type linearTrackSegment struct {
+	from, track float64
+	moves       bool
+	joints      [][]referenceframe.Input
+}
+
+// segmentLinearTrackMove groups a move's waypoints into segments, starting with the track at start.
+// The track counts as moving only once a waypoint is more than linearTrackTolerance from the last
+// position it moved to, so small differences from the planner don't break up the xArm's trajectory.
+func segmentLinearTrackMove(start float64, tracks []float64, joints [][]referenceframe.Input) []linearTrackSegment {
+	var out []linearTrackSegment
+	cur := start
+	for i, track := range tracks {
+		if math.Abs(track-cur) > linearTrackTolerance {
+			out = append(out, linearTrackSegment{from: cur, track: track, moves: true, joints: joints[i : i+1]})
+			cur = track
+			continue
+		}
+		if n := len(out); n > 0 && !out[n-1].moves {
+			out[n-1].joints = append(out[n-1].joints, joints[i])
+			continue
+		}
+		out = append(out, linearTrackSegment{from: cur, track: cur, joints: [][]referenceframe.Input{joints[i]}})
+	}
+	return out
+}
+
+// linearTrackSyncSpeed is the speed that takes the track distMM in armTime, so it arrives with the
+// xArm. It is capped at the track's top speed, in which case the xArm arrives first. With no xArm
+// motion to match it is 0, the configured speed.
+func linearTrackSyncSpeed(distMM float64, armTime time.Duration) float64 {
+	if armTime <= 0 {
+		return 0
+	}
+	return math.Min(math.Max(distMM/armTime.Seconds(), minLinearTrackSpeed), maxLinearTrackSpeed)
+}
+
+func (a *linearTrackArm) MoveThroughJointPositionsStreamed(
+	ctx context.Context,
+	batches <-chan []arm.TrajectoryPoint,
+	responses chan<- arm.Response,
+	extra map[string]any,
+) error {
+	return errors.New("linear track arm does not support streamed trajectories")
+}
+
+func (a *linearTrackArm) Get3DModels(ctx context.Context, extra map[string]any) (map[string]*commonpb.Mesh, error) {
+	if !a.combined {
+		return map[string]*commonpb.Mesh{}, nil
+	}
+	return a.x.Get3DModels(ctx, extra)
+}
+
+func (a *linearTrackArm) IsMoving(ctx context.Context) (bool, error) {
+	if a.isMoving.Load() || !a.combined {
+		return a.isMoving.Load(), nil
+	}
+	return a.x.IsMoving(ctx)
+}
+
+// Stop halts the track and, when combined, the xArm.
+func (a *linearTrackArm) Stop(ctx context.Context, extra map[string]any) error {
+	err := a.linearTrack.Stop(ctx, extra)
+	if a.combined {
+		err = errors.Join(err, a.x.Stop(ctx, extra))
+	}
+	return err
+}
+
+func (a *linearTrackArm) Close(ctx context.Context) error {
+	return a.linearTrack.Stop(ctx, nil)
+}
+
+func (a *linearTrackArm) Geometries(ctx context.Context, _ map[string]any) ([]spatialmath.Geometry, error) {
+	inputs, err := a.CurrentInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gif, err := a.mf.Geometries(inputs)
+	if err != nil {
+		return nil, err
+	}
+	return gif.Geometries(), nil
+}
+
+func (a *linearTrackArm) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	return a.mf, nil
+}
+
+func (a *linearTrackArm) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	return a.JointPositions(ctx, nil)
+}
+
+func (a *linearTrackArm) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	return a.MoveThroughJointPositions(ctx, inputSteps, nil, nil)
+}
+
+// linearTrackModelConfig is the track's kinematic model: a fixed rail along +X and a prismatic joint
+// carrying the carriage over length mm of travel.
+func linearTrackModelConfig(length float64) *referenceframe.ModelConfigJSON {
+	railLength := length + linearTrackCarriageLength
+	return &referenceframe.ModelConfigJSON{
+		Name: ModelNameLinearTrack,
+		Links: []referenceframe.LinkConfig{
+			{
+				ID:     linearTrackRailFrame,
+				Parent: referenceframe.World,
+				Geometry: &spatialmath.GeometryConfig{
+					Type: spatialmath.BoxType,
+					X:    railLength,
+					Y:    linearTrackRailWidth,
+					Z:    linearTrackRailHeight,
+					TranslationOffset: r3.Vector{
+						X: length / 2,
+						Z: -linearTrackCarriageHeight - linearTrackRailHeight/2,
+					},
+				},
+			},
+			// Joints carry no geometry, so the carriage is a link riding on the joint.
+			{
+				ID:     linearTrackCarriageFrame,
+				Parent: linearTrackJointFrame,
+				Geometry: &spatialmath.GeometryConfig{
+					Type:              spatialmath.BoxType,
+					X:                 linearTrackCarriageLength,
+					Y:                 linearTrackRailWidth,
+					Z:                 linearTrackCarriageHeight,
+					TranslationOffset: r3.Vector{Z: -linearTrackCarriageHeight / 2},
+				},
+			},
+		},
+		Joints: []referenceframe.JointConfig{{
+			ID:     linearTrackJointFrame,
+			Type:   referenceframe.PrismaticJoint,
+			Parent: linearTrackRailFrame,
+			Axis:   spatialmath.AxisConfig{X: 1},
+			Min:    0,
+			Max:    length,
+		}},
+	}
+}
+
+// combineLinearTrackModelConfig appends the xArm's links and joints to the track's, with the xArm's
+// base offset from the carriage by offset. The xArm's OriginalFile is dropped, since it may be a
+// URDF; parseModelConfig writes one for the whole chain.
+func combineLinearTrackModelConfig(
+	track, xarm *referenceframe.ModelConfigJSON,
+	offset r3.Vector,
+) (*referenceframe.ModelConfigJSON, error) {
+	if xarm == nil || len(xarm.Links) == 0 {
+		return nil, errors.New("the xArm's kinematic model has no links to combine with the track")
+	}
+	if xarm.KinParamType != "" && xarm.KinParamType != "SVA" {
+		return nil, fmt.Errorf("cannot combine the track with %s kinematics", xarm.KinParamType)
+	}
+	cfg := *track
+	cfg.Links = append(append([]referenceframe.LinkConfig(nil), track.Links...), referenceframe.LinkConfig{
+		ID:          linearTrackMountFrame,
+		Parent:      linearTrackCarriageFrame,
+		Translation: offset,
+	})
+	for _, l := range xarm.Links {
+		if l.Parent == "" || l.Parent == referenceframe.World {
+			l.Parent = linearTrackMountFrame
+		}
+		cfg.Links = append(cfg.Links, l)
+	}
+	cfg.Joints = append(append([]referenceframe.JointConfig(nil), track.Joints...), xarm.Joints...)
+	for _, j := range cfg.Joints[len(track.Joints):] {
+		if j.Parent == "" || j.Parent == referenceframe.World {
+			return nil, fmt.Errorf("xArm joint %s has no parent link to mount on the track by", j.ID)
+		}
+	}
+	cfg.Name = ModelNameLinearTrack + "_" + xarm.Name
+	cfg.OutputFrames = xarm.OutputFrames
+	return &cfg, nil
+}
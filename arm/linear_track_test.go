@@ -0,0 +1,161 @@
+package arm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/test"
+)
+
+func TestLinearTrackConfigValidate(t *testing.T) {
+	deps, _, err := (&LinearTrackConfig{Arm: "a"}).Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"a"})
+	_, _, err = (&LinearTrackConfig{
+		Arm: "a", SlaveID: 1, BaudRate: 2000000, LengthMM: 1500, SpeedMMPerSec: 1000,
+		CombineArm: true, ArmOffsetMM: &r3.Vector{Z: 10},
+	}).Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+
+	_, _, err = (&LinearTrackConfig{}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&LinearTrackConfig{Arm: "a", SlaveID: 248}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&LinearTrackConfig{Arm: "a", LengthMM: -1}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&LinearTrackConfig{Arm: "a", SpeedMMPerSec: 1001}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&LinearTrackConfig{Arm: "a", ArmOffsetMM: &r3.Vector{}}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDecodeLinearTrackStatus(t *testing.T) {
+	// 350.5 mm, moving, no error, enabled and homed.
+	s, err := decodeLinearTrackStatus([]uint16{0x000A, 0xB248, 1, 0, 1, 1})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, s.position, test.ShouldAlmostEqual, 350.5)
+	test.That(t, s.moving, test.ShouldBeTrue)
+	test.That(t, s.enabled, test.ShouldBeTrue)
+	test.That(t, s.homed, test.ShouldBeTrue)
+	test.That(t, s.err(), test.ShouldBeNil)
+
+	// Positions are signed, and just behind the origin switch reads negative.
+	s, err = decodeLinearTrackStatus([]uint16{0xFFFF, 0xF830, 0, 22, 0, 0})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, s.position, test.ShouldAlmostEqual, -1)
+	test.That(t, s.err(), test.ShouldNotBeNil)
+	test.That(t, s.toMap()["error_code"], test.ShouldEqual, 22)
+
+	_, err = decodeLinearTrackStatus([]uint16{0, 0})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// A whole read response from the control box bus: header, byte count, then the block from 0x0A20.
+	// 700 mm, stopped, no error, enabled and homed.
+	frame := []byte{0, controlBoxModbusHostID, defaultLinearTrackSlaveID, modbusReadHolding, 2 * byte(linearTrackStatusRegs),
+		0x00, 0x15, 0x5C, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01}
+	r, err := decodeGripperRegRead(linearTrackStatusReg, linearTrackStatusRegs, frame)
+	test.That(t, err, test.ShouldBeNil)
+	s, err = decodeLinearTrackStatus(r.words())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, s, test.ShouldResemble, linearTrackStatus{position: 700, enabled: true, homed: true})
+}
+
+func TestSegmentLinearTrackMove(t *testing.T) {
+	j := func(v float64) []referenceframe.Input { return []referenceframe.Input{v} }
+	joints := [][]referenceframe.Input{j(0), j(1), j(2), j(3), j(4), j(5)}
+
+	// Differences within the tolerance keep the xArm's waypoints together; a real track move is one
+	// waypoint on its own.
+	segs := segmentLinearTrackMove(100, []float64{100, 100.4, 99.7, 300, 300.2, 300}, joints)
+	test.That(t, segs, test.ShouldResemble, []linearTrackSegment{
+		{from: 100, track: 100, joints: joints[:3]},
+		{from: 100, track: 300, moves: true, joints: joints[3:4]},
+		{from: 300, track: 300, joints: joints[4:]},
+	})
+
+	// Small steps that add up past the tolerance move the track.
+	segs = segmentLinearTrackMove(0, []float64{0.6, 1.2, 1.8}, joints[:3])
+	test.That(t, segs, test.ShouldHaveLength, 3)
+	test.That(t, segs[1], test.ShouldResemble, linearTrackSegment{from: 0, track: 1.2, moves: true, joints: joints[1:2]})
+	test.That(t, segs[0].joints, test.ShouldResemble, joints[:1])
+}
+
+func TestLinearTrackSyncSpeed(t *testing.T) {
+	test.That(t, linearTrackSyncSpeed(200, 2*time.Second), test.ShouldEqual, 100.)
+	test.That(t, linearTrackSyncSpeed(200, 0), test.ShouldEqual, 0.)
+	test.That(t, linearTrackSyncSpeed(5000, time.Second), test.ShouldEqual, maxLinearTrackSpeed)
+	test.That(t, linearTrackSyncSpeed(0.1, 10*time.Second), test.ShouldEqual, minLinearTrackSpeed)
+}
+
+func TestLinearTrackRegs(t *testing.T) {
+	test.That(t, linearTrackTargetRegs(350.5), test.ShouldResemble, []uint16{0x000A, 0xB248})
+	test.That(t, linearTrackTargetRegs(-1), test.ShouldResemble, []uint16{0xFFFF, 0xF830})
+	test.That(t, linearTrackSpeedReg16(200), test.ShouldEqual, uint16(1333))
+}
+
+func TestLinearTrackModel(t *testing.T) {
+	mf, err := parseModelConfig(ModelNameLinearTrack, linearTrackModelConfig(1000))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mf.DoF(), test.ShouldHaveLength, 1)
+	test.That(t, mf.DoF()[0].Max, test.ShouldEqual, 1000.)
+
+	pose, err := mf.Transform([]referenceframe.Input{250})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.R3VectorAlmostEqual(pose.Point(), r3.Vector{X: 250}, 1e-6), test.ShouldBeTrue)
+
+	// The carriage rides the joint, the rail stays put.
+	gif, err := mf.Geometries([]referenceframe.Input{250})
+	test.That(t, err, test.ShouldBeNil)
+	geoms := map[string]spatialmath.Geometry{}
+	for _, g := range gif.Geometries() {
+		geoms[g.Label()] = g
+	}
+	carriage := ModelNameLinearTrack + ":" + linearTrackCarriageFrame
+	rail := ModelNameLinearTrack + ":" + linearTrackRailFrame
+	test.That(t, geoms, test.ShouldContainKey, carriage)
+	test.That(t, geoms, test.ShouldContainKey, rail)
+	test.That(t, geoms[carriage].Pose().Point().X, test.ShouldAlmostEqual, 250)
+	test.That(t, geoms[rail].Pose().Point().X, test.ShouldAlmostEqual, 500)
+
+	// Clients rebuild the model from the kinematics file.
+	rebuilt, err := referenceframe.KinematicModelFromProtobuf(ModelNameLinearTrack, referenceframe.KinematicModelToProtobuf(mf))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rebuilt.DoF(), test.ShouldResemble, mf.DoF())
+}
+
+func TestCombineLinearTrackModel(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	for _, useURDFs := range []bool{false, true} {
+		armModel, err := MakeModelFrame("arm", ModelName6DOF, nil, nil, useURDFs, nil, logger, 0)
+		if useURDFs && err != nil {
+			// The URDFs are found through VIAM_MODULE_ROOT, which only the module's runtime sets.
+			continue
+		}
+		test.That(t, err, test.ShouldBeNil)
+
+		offset := r3.Vector{X: 10, Z: 5}
+		cfg, err := combineLinearTrackModelConfig(linearTrackModelConfig(700), armModel.ModelConfig(), offset)
+		test.That(t, err, test.ShouldBeNil)
+		mf, err := parseModelConfig("track", cfg)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, mf.DoF(), test.ShouldHaveLength, 1+len(armModel.DoF()))
+		test.That(t, mf.DoF()[0].Max, test.ShouldEqual, 700.)
+		test.That(t, mf.DoF()[1:], test.ShouldResemble, armModel.DoF())
+
+		// The arm's end pose is carried along the track and by the mount offset.
+		joints := make([]referenceframe.Input, len(armModel.DoF()))
+		armPose, err := armModel.Transform(joints)
+		test.That(t, err, test.ShouldBeNil)
+		pose, err := mf.Transform(append([]referenceframe.Input{300}, joints...))
+		test.That(t, err, test.ShouldBeNil)
+		want := armPose.Point().Add(offset).Add(r3.Vector{X: 300})
+		test.That(t, spatialmath.R3VectorAlmostEqual(pose.Point(), want, 1e-6), test.ShouldBeTrue)
+	}
+
+	_, err := combineLinearTrackModelConfig(linearTrackModelConfig(700), &referenceframe.ModelConfigJSON{}, r3.Vector{})
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,460 @@
+package arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	rutils "go.viam.com/rdk/utils"
+	goutils "go.viam.com/utils"
+)
+
+// A Modbus device is any Modbus RTU device the arm can reach over one of its RS-485 buses, such as a
+// screwdriver, a dispenser or a small sensor on the tool port, or a conveyor or turntable on the
+// control box port. It is a sensor whose Readings are the registers named in its config, and whose
+// DoCommands read and write any register on any slave id.
+
+const (
+	// ModelNameToolModbus is a Modbus RTU device on the arm's tool RS-485 port.
+	ModelNameToolModbus = "tool_modbus"
+	// ModelNameControlBoxModbus is a Modbus RTU device on the control box's RS-485 port.
+	ModelNameControlBoxModbus = "control_box_modbus"
+)
+
+var (
+	// ToolModbusModel model for a Modbus device on the tool RS-485 port.
+	ToolModbusModel = family.WithModel(ModelNameToolModbus)
+	// ControlBoxModbusModel model for a Modbus device on the control box RS-485 port.
+	ControlBoxModbusModel = family.WithModel(ModelNameControlBoxModbus)
+)
+
+const (
+	readHoldingRegistersKey = "read_holding_registers"
+	readInputRegistersKey   = "read_input_registers"
+	writeRegistersKey       = "write_registers"
+
+	modbusRegisterHolding = "holding"
+	modbusRegisterInput   = "input"
+)
+
+// Register data types. Multi-register values are big-endian, high word first.
+const (
+	modbusUint16  = "uint16"
+	modbusInt16   = "int16"
+	modbusUint32  = "uint32"
+	modbusInt32   = "int32"
+	modbusFloat32 = "float32"
+)
+
+// modbusBus is an RS-485 bus the arm tunnels Modbus RTU to.
+type modbusBus interface {
+	readRegisters(ctx context.Context, slave, function byte, addr, count uint16) (gripperRegRead, error)
+	// writeRegisters returns the Modbus exception the device answered with, or 0.
+	writeRegisters(ctx context.Context, slave byte, addr uint16, values []uint16) (byte, error)
+	setBaud(ctx context.Context, baud int) error
+}
+
+// rs485Bus is one of the arm's RS-485 buses, named by its host id. Both share gripperConn, and its
+// locking, with the grippers.
+type rs485Bus struct {
+	x    *xArm
+	host byte
+}
+
+func (b rs485Bus) readRegisters(ctx context.Context, slave, function byte, addr, count uint16) (gripperRegRead, error) {
+	return b.x.readRS485Registers(ctx, b.host, slave, function, addr, count)
+}
+
+func (b rs485Bus) writeRegisters(ctx context.Context, slave byte, addr uint16, values []uint16) (byte, error) {
+	return b.x.writeRS485Registers(ctx, b.host, slave, addr, values)
+}
+
+func (b rs485Bus) setBaud(ctx context.Context, baud int) error {
+	return b.x.setRS485Baud(ctx, b.host, baud)
+}
+
+// ModbusRegisterConfig names a register, or a pair of them for 32-bit types, to report in Readings.
+type ModbusRegisterConfig struct {
+	Name    string `json:"name"`
+	Address int    `json:"address"`
+	// Type is "holding" (the default) or "input".
+	Type string `json:"type,omitempty"`
+	// DataType is "uint16" (the default), "int16", "uint32", "int32" or "float32".
+	DataType string `json:"data_type,omitempty"`
+	// Scale multiplies the value when it is not 0, turning raw counts into units.
+	Scale float64 `json:"scale,omitempty"`
+	// SlaveID overrides the device's slave id for this register.
+	SlaveID int `json:"slave_id,omitempty"`
+}
+
+func (cfg *ModbusRegisterConfig) validate() error {
+	if cfg.Name == "" {
+		return errors.New("registers need a name")
+	}
+	if cfg.Address < 0 || cfg.Address > math.MaxUint16 {
+		return fmt.Errorf("register %q address must be between 0 and %d, got %d", cfg.Name, math.MaxUint16, cfg.Address)
+	}
+	switch cfg.Type {
+	case "", modbusRegisterHolding, modbusRegisterInput:
+	default:
+		return fmt.Errorf("register %q type must be %q or %q, got %q", cfg.Name, modbusRegisterHolding, modbusRegisterInput, cfg.Type)
+	}
+	if _, err := modbusRegisterCount(cfg.DataType); err != nil {
+		return fmt.Errorf("register %q: %w", cfg.Name, err)
+	}
+	if cfg.SlaveID < 0 || cfg.SlaveID > maxModbusSlaveID {
+		return fmt.Errorf("register %q slave_id must be between 1 and %d, got %d", cfg.Name, maxModbusSlaveID, cfg.SlaveID)
+	}
+	return nil
+}
+
+func (cfg *ModbusRegisterConfig) function() byte {
+	if cfg.Type == modbusRegisterInput {
+		return modbusReadInput
+	}
+	return modbusReadHolding
+}
+
+// ModbusDeviceConfig config for a Modbus device.
+type ModbusDeviceConfig struct {
+	Arm     string `json:"arm"`
+	SlaveID int    `json:"slave_id"`
+	// BaudRate switches the bus to this rate at startup. 0 leaves the bus as it is.
+	BaudRate  int                    `json:"baud_rate,omitempty"`
+	Registers []ModbusRegisterConfig `json:"registers,omitempty"`
+	// PollIntervalSec polls the registers in the background, and Readings returns the latest poll.
+	// 0 reads them when Readings is called.
+	PollIntervalSec float64 `json:"poll_interval_sec,omitempty"`
+}
+
+// Validate validates the config.
+func (cfg *ModbusDeviceConfig) Validate(path string) ([]string, []string, error) {
+	if cfg.Arm == "" {
+		return nil, nil, goutils.NewConfigValidationFieldRequiredError(path, "arm")
+	}
+	if cfg.SlaveID == 0 {
+		return nil, nil, goutils.NewConfigValidationFieldRequiredError(path, "slave_id")
+	}
+	if err := validateRS485(cfg.SlaveID, cfg.BaudRate); err != nil {
+		return nil, nil, err
+	}
+	names := map[string]bool{}
+	for i := range cfg.Registers {
+		r := &cfg.Registers[i]
+		if err := r.validate(); err != nil {
+			return nil, nil, err
+		}
+		if names[r.Name] {
+			return nil, nil, fmt.Errorf("register name %q is used more than once", r.Name)
+		}
+		names[r.Name] = true
+	}
+	if cfg.PollIntervalSec < 0 {
+		return nil, nil, fmt.Errorf("poll_interval_sec cannot be negative, got %f", cfg.PollIntervalSec)
+	}
+	return []string{cfg.Arm}, nil, nil
+}
+
+// modbusRegisterCount returns how many registers a data type spans.
+func modbusRegisterCount(dataType string) (uint16, error) {
+	switch dataType {
+	case "", modbusUint16, modbusInt16:
+		return 1, nil
+	case modbusUint32, modbusInt32, modbusFloat32:
+		return 2, nil
+	default:
+		return 0, fmt.Errorf("data_type must be one of %s, %s, %s, %s or %s, got %q",
+			modbusUint16, modbusInt16, modbusUint32, modbusInt32, modbusFloat32, dataType)
+	}
+}
+
+// decodeModbusValue decodes the words read for a register. With a scale it returns a float64.
+func decodeModbusValue(words []uint16, dataType string, scale float64) (any, error) {
+	count, err := modbusRegisterCount(dataType)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) != int(count) {
+		return nil, fmt.Errorf("%s needs %d registers, got %d", dataType, count, len(words))
+	}
+	var v float64
+	switch dataType {
+	case "", modbusUint16:
+		v = float64(words[0])
+	case modbusInt16:
+		v = float64(int16(words[0])) //nolint:gosec // reinterpreting the register's bits.
+	case modbusUint32:
+		v = float64(uint32(words[0])<<16 | uint32(words[1]))
+	case modbusInt32:
+		v = float64(int32(uint32(words[0])<<16 | uint32(words[1]))) //nolint:gosec // reinterpreting the bits.
+	case modbusFloat32:
+		v = float64(math.Float32frombits(uint32(words[0])<<16 | uint32(words[1])))
+	}
+	if scale != 0 {
+		return v * scale, nil
+	}
+	if dataType == modbusFloat32 {
+		return v, nil
+	}
+	return int64(v), nil
+}
+
+func init() {
+	resource.RegisterComponent(
+		sensor.API,
+		ToolModbusModel,
+		resource.Registration[sensor.Sensor, *ModbusDeviceConfig]{
+			Constructor: modbusDeviceConstructor(toolModbusHostID),
+		})
+	resource.RegisterComponent(
+		sensor.API,
+		ControlBoxModbusModel,
+		resource.Registration[sensor.Sensor, *ModbusDeviceConfig]{
+			Constructor: modbusDeviceConstructor(controlBoxModbusHostID),
+		})
+}
+
+// modbusDevice reports a Modbus device's registers as sensor readings.
+type modbusDevice struct {
+	resource.AlwaysRebuild
+
+	name      resource.Name
+	bus       modbusBus
+	slave     byte
+	registers []ModbusRegisterConfig
+	logger    logging.Logger
+
+	workers *goutils.StoppableWorkers
+	mu      sync.Mutex
+	latest  map[string]any
+	polled  time.Time
+	pollErr error
+}
+
+// modbusDeviceConstructor builds Modbus devices on host's bus.
+func modbusDeviceConstructor(host byte) resource.Create[sensor.Sensor] {
+	return func(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+		newConf, err := resource.NativeConfig[*ModbusDeviceConfig](conf)
+		if err != nil {
+			return nil, err
+		}
+		a, err := arm.FromProvider(deps, newConf.Arm)
+		if err != nil {
+			return nil, err
+		}
+		x, err := rutils.AssertType[*xArm](a)
+		if err != nil {
+			return nil, fmt.Errorf("%s modbus: %w", rs485BusName(host), err)
+		}
+		return newModbusDevice(ctx, conf.ResourceName(), rs485Bus{x: x, host: host}, newConf, logger)
+	}
+}
+
+func newModbusDevice(ctx context.Context, name resource.Name, bus modbusBus, conf *ModbusDeviceConfig, logger logging.Logger) (
+	*modbusDevice, error,
+) {
+	if conf.BaudRate != 0 {
+		if err := bus.setBaud(ctx, conf.BaudRate); err != nil {
+			return nil, err
+		}
+	}
+	d := &modbusDevice{
+		name:      name,
+		bus:       bus,
+		slave:     byte(conf.SlaveID),
+		registers: conf.Registers,
+		logger:    logger,
+	}
+	if conf.PollIntervalSec > 0 && len(conf.Registers) > 0 {
+		interval := time.Duration(conf.PollIntervalSec * float64(time.Second))
+		d.workers = goutils.NewBackgroundStoppableWorkers(func(ctx context.Context) { d.poll(ctx, interval) })
+	}
+	return d, nil
+}
+
+func (d *modbusDevice) slaveFor(r *ModbusRegisterConfig) byte {
+	if r.SlaveID != 0 {
+		return byte(r.SlaveID)
+	}
+	return d.slave
+}
+
+// readRegisters reads every configured register.
+func (d *modbusDevice) readRegisters(ctx context.Context) (map[string]any, error) {
+	out := make(map[string]any, len(d.registers))
+	for i := range d.registers {
+		r := &d.registers[i]
+		count, err := modbusRegisterCount(r.DataType)
+		if err != nil {
+			return nil, err
+		}
+		words, err := d.read(ctx, d.slaveFor(r), r.function(), uint16(r.Address), count) //nolint:gosec // validated.
+		if err != nil {
+			return nil, fmt.Errorf("register %q: %w", r.Name, err)
+		}
+		if out[r.Name], err = decodeModbusValue(words, r.DataType, r.Scale); err != nil {
+			return nil, fmt.Errorf("register %q: %w", r.Name, err)
+		}
+	}
+	return out, nil
+}
+
+func (d *modbusDevice) read(ctx context.Context, slave, function byte, addr, count uint16) ([]uint16, error) {
+	r, err := d.bus.readRegisters(ctx, slave, function, addr, count)
+	if err != nil {
+		return nil, err
+	}
+	if r.exception != 0 {
+		return nil, fmt.Errorf("slave %d rejected a read of 0x%04X with Modbus exception 0x%02X", slave, addr, r.exception)
+	}
+	return r.words(), nil
+}
+
+func (d *modbusDevice) poll(ctx context.Context, interval time.Duration) {
+	for goutils.SelectContextOrWait(ctx, interval) {
+		values, err := d.readRegisters(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		d.mu.Lock()
+		// Log each distinct failure once rather than at every poll.
+		if err != nil && (d.pollErr == nil || d.pollErr.Error() != err.Error()) {
+			d.logger.Warnf("modbus poll: %v", err)
+		}
+		d.pollErr = err
+		if err == nil {
+			d.latest, d.polled = values, time.Now()
+		}
+		d.mu.Unlock()
+	}
+}
+
+func (d *modbusDevice) Readings(ctx context.Context, extra map[string]any) (map[string]any, error) {
+	if d.workers == nil {
+		return d.readRegisters(ctx)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pollErr != nil {
+		return nil, d.pollErr
+	}
+	if d.latest == nil {
+		return nil, errors.New("no registers have been polled yet")
+	}
+	out := make(map[string]any, len(d.latest))
+	for k, v := range d.latest {
+		out[k] = v
+	}
+	return out, nil
+}
+
+// registerArgs parses the arguments of a register DoCommand: an address, a slave id defaulting to
+// the device's, and a count defaulting to 1.
+func (d *modbusDevice) registerArgs(val any) (args map[string]any, slave byte, addr, count uint16, err error) {
+	args, ok := val.(map[string]any)
+	if !ok {
+		return nil, 0, 0, 0, fmt.Errorf("expected a map with address, got %T", val)
+	}
+	a, ok := f64(args, "address")
+	if !ok || a < 0 || a > math.MaxUint16 || a != math.Trunc(a) {
+		return nil, 0, 0, 0, fmt.Errorf("address must be an integer between 0 and %d", math.MaxUint16)
+	}
+	slave = d.slave
+	if s, ok := f64(args, "slave_id"); ok {
+		if s < 1 || s > maxModbusSlaveID || s != math.Trunc(s) {
+			return nil, 0, 0, 0, fmt.Errorf("slave_id must be an integer between 1 and %d", maxModbusSlaveID)
+		}
+		slave = byte(s)
+	}
+	count = 1
+	if c, ok := f64(args, "count"); ok {
+		if c < 1 || c > maxModbusReadCount || c != math.Trunc(c) {
+			return nil, 0, 0, 0, fmt.Errorf("count must be an integer between 1 and %d", maxModbusReadCount)
+		}
+		count = uint16(c)
+	}
+	return args, slave, uint16(a), count, nil
+}
+
+// modbusWriteValues parses the values of a write_registers DoCommand.
+func modbusWriteValues(args map[string]any) ([]uint16, error) {
+	raw, ok := args["values"].([]any)
+	if !ok || len(raw) == 0 || len(raw) > maxModbusWriteCount {
+		return nil, fmt.Errorf("values must be a list of 1 to %d register values", maxModbusWriteCount)
+	}
+	values := make([]uint16, len(raw))
+	for i, v := range raw {
+		f, ok := v.(float64)
+		if !ok || f != math.Trunc(f) || f < math.MinInt16 || f > math.MaxUint16 {
+			return nil, fmt.Errorf("values[%d] must be an integer register value, got %v", i, v)
+		}
+		// Negative values are written as their two's complement, for int16 registers.
+		values[i] = uint16(int32(f)) //nolint:gosec // bounded above.
+	}
+	return values, nil
+}
+
+func (d *modbusDevice) DoCommand(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	for _, read := range []struct {
+		key      string
+		function byte
+	}{{readHoldingRegistersKey, modbusReadHolding}, {readInputRegistersKey, modbusReadInput}} {
+		if val, ok := cmd[read.key]; ok {
+			_, slave, addr, count, err := d.registerArgs(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", read.key, err)
+			}
+			words, err := d.read(ctx, slave, read.function, addr, count)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"registers": words}, nil
+		}
+	}
+	if val, ok := cmd[writeRegistersKey]; ok {
+		args, slave, addr, _, err := d.registerArgs(val)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", writeRegistersKey, err)
+		}
+		values, err := modbusWriteValues(args)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", writeRegistersKey, err)
+		}
+		exc, err := d.bus.writeRegisters(ctx, slave, addr, values)
+		if err != nil {
+			return nil, err
+		}
+		if exc != 0 {
+			return nil, fmt.Errorf("slave %d rejected a write to 0x%04X with Modbus exception 0x%02X", slave, addr, exc)
+		}
+		return map[string]any{"written": len(values)}, nil
+	}
+	return map[string]any{}, nil
+}
+
+func (d *modbusDevice) Name() resource.Name {
+	return d.name
+}
+
+func (d *modbusDevice) Close(ctx context.Context) error {
+	if d.workers != nil {
+		d.workers.Stop()
+	}
+	return nil
+}
+
+func (d *modbusDevice) Status(_ context.Context) (map[string]any, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.polled.IsZero() {
+		return map[string]any{}, nil
+	}
+	return map[string]any{"last_poll": d.polled.Format(time.RFC3339Nano)}, nil
+}
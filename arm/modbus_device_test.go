@@ -0,0 +1,177 @@
+package arm
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/test"
+)
+
+// fakeModbusBus is a bus of holding and input registers keyed by slave and address.
+type fakeModbusBus struct {
+	holding, input map[byte]map[uint16]uint16
+	baud           int
+}
+
+func (b *fakeModbusBus) readRegisters(_ context.Context, slave, function byte, addr, count uint16) (gripperRegRead, error) {
+	regs := b.holding[slave]
+	if function == modbusReadInput {
+		regs = b.input[slave]
+	}
+	params := []byte{0, toolModbusHostID, slave, function, byte(2 * count)}
+	for i := range count {
+		v, ok := regs[addr+i]
+		if !ok {
+			return decodeGripperRegRead(addr, count, []byte{0, toolModbusHostID, slave, function | 0x80, 0x02})
+		}
+		params = binary.BigEndian.AppendUint16(params, v)
+	}
+	return decodeGripperRegRead(addr, count, params)
+}
+
+func (b *fakeModbusBus) writeRegisters(_ context.Context, slave byte, addr uint16, values []uint16) (byte, error) {
+	regs, ok := b.holding[slave]
+	if !ok {
+		return 0x02, nil
+	}
+	for i, v := range values {
+		regs[addr+uint16(i)] = v //nolint:gosec
+	}
+	return 0, nil
+}
+
+func (b *fakeModbusBus) setBaud(_ context.Context, baud int) error {
+	b.baud = baud
+	return nil
+}
+
+func TestModbusDeviceConfigValidate(t *testing.T) {
+	valid := func() *ModbusDeviceConfig {
+		return &ModbusDeviceConfig{Arm: "a", SlaveID: 1, Registers: []ModbusRegisterConfig{
+			{Name: "torque", Address: 0x100, DataType: "int32", Scale: 0.01},
+			{Name: "state", Address: 0x10, Type: "input"},
+		}}
+	}
+	_, _, err := valid().Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+
+	cfg := valid()
+	cfg.SlaveID = 0
+	_, _, err = cfg.Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	cfg = valid()
+	cfg.Registers[1].Name = "torque"
+	_, _, err = cfg.Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	cfg = valid()
+	cfg.Registers[0].DataType = "float64"
+	_, _, err = cfg.Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	cfg = valid()
+	cfg.Registers[1].Type = "coil"
+	_, _, err = cfg.Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	cfg = valid()
+	cfg.Registers[0].Address = 70000
+	_, _, err = cfg.Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDecodeModbusValue(t *testing.T) {
+	v, err := decodeModbusValue([]uint16{0xFFFF}, "", 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, v, test.ShouldEqual, int64(65535))
+	v, err = decodeModbusValue([]uint16{0xFFFF}, modbusInt16, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, v, test.ShouldEqual, int64(-1))
+	v, err = decodeModbusValue([]uint16{0x0001, 0x0000}, modbusUint32, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, v, test.ShouldEqual, int64(65536))
+	v, err = decodeModbusValue([]uint16{0xFFFF, 0xFF9C}, modbusInt32, 0.1)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, v, test.ShouldAlmostEqual, -10.0)
+	bits := math.Float32bits(1.5)
+	v, err = decodeModbusValue([]uint16{uint16(bits >> 16), uint16(bits)}, modbusFloat32, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, v, test.ShouldEqual, 1.5)
+
+	_, err = decodeModbusValue([]uint16{1}, modbusInt32, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestModbusDevice(t *testing.T) {
+	ctx := context.Background()
+	bus := &fakeModbusBus{
+		holding: map[byte]map[uint16]uint16{3: {0x100: 0xFFFF, 0x101: 0xFF9C}, 4: {0x20: 7}},
+		input:   map[byte]map[uint16]uint16{3: {0x10: 2}},
+	}
+	conf := &ModbusDeviceConfig{Arm: "a", SlaveID: 3, BaudRate: 115200, Registers: []ModbusRegisterConfig{
+		{Name: "torque", Address: 0x100, DataType: modbusInt32, Scale: 0.01},
+		{Name: "state", Address: 0x10, Type: modbusRegisterInput},
+		{Name: "other", Address: 0x20, SlaveID: 4},
+	}}
+	d, err := newModbusDevice(ctx, resource.NewName(resource.APINamespaceRDK.WithComponentType("sensor"), "d"), bus, conf,
+		logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, bus.baud, test.ShouldEqual, 115200)
+
+	readings, err := d.Readings(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings["torque"], test.ShouldAlmostEqual, -1.0)
+	test.That(t, readings["state"], test.ShouldEqual, int64(2))
+	test.That(t, readings["other"], test.ShouldEqual, int64(7))
+
+	res, err := d.DoCommand(ctx, map[string]any{readHoldingRegistersKey: map[string]any{"address": float64(0x100), "count": 2.0}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, res["registers"], test.ShouldResemble, []uint16{0xFFFF, 0xFF9C})
+
+	res, err = d.DoCommand(ctx, map[string]any{writeRegistersKey: map[string]any{
+		"slave_id": 4.0, "address": float64(0x20), "values": []any{-2.0, 300.0},
+	}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, res["written"], test.ShouldEqual, 2)
+	test.That(t, bus.holding[4][0x20], test.ShouldEqual, uint16(0xFFFE))
+	test.That(t, bus.holding[4][0x21], test.ShouldEqual, uint16(300))
+
+	// A device that refuses the address answers with an exception, which is an error.
+	_, err = d.DoCommand(ctx, map[string]any{readInputRegistersKey: map[string]any{"address": 5.0}})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "exception")
+	_, err = d.DoCommand(ctx, map[string]any{writeRegistersKey: map[string]any{"slave_id": 9.0, "address": 1.0, "values": []any{1.0}}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = d.DoCommand(ctx, map[string]any{writeRegistersKey: map[string]any{"address": 1.0, "values": []any{70000.0}}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = d.DoCommand(ctx, map[string]any{readHoldingRegistersKey: map[string]any{"count": 2.0}})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestModbusDevicePolling(t *testing.T) {
+	ctx := context.Background()
+	bus := &fakeModbusBus{holding: map[byte]map[uint16]uint16{1: {0: 42}}}
+	conf := &ModbusDeviceConfig{Arm: "a", SlaveID: 1, PollIntervalSec: 0.005, Registers: []ModbusRegisterConfig{{Name: "v", Address: 0}}}
+	d, err := newModbusDevice(ctx, resource.NewName(resource.APINamespaceRDK.WithComponentType("sensor"), "d"), bus, conf,
+		logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	defer func() { test.That(t, d.Close(ctx), test.ShouldBeNil) }()
+
+	deadline := time.Now().Add(time.Second)
+	readings, err := d.Readings(ctx, nil)
+	for err != nil && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		readings, err = d.Readings(ctx, nil)
+	}
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings["v"], test.ShouldEqual, int64(42))
+	status, err := d.Status(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, status["last_poll"], test.ShouldNotBeNil)
+}
@@ -0,0 +1,206 @@
+package arm
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// A move may override the collision sensitivity and payload with `collision_sensitivity` and `payload`
+// in `extra`. The overrides are sent to the controller before the move and put back afterwards, even if
+// the move fails or is cancelled. The controller's values can't be read, so a setting may only be
+// overridden when the config sets it, and it is put back to the configured value. A move that returns
+// before the arm stops keeps its overrides until the arm has stopped.
+
+const (
+	collisionSensitivityKey = "collision_sensitivity"
+	payloadKey              = "payload"
+
+	// overrideRestoreTimeout bounds putting the settings back, which runs even once the move's
+	// context is done.
+	overrideRestoreTimeout = 5 * time.Second
+)
+
+// PayloadConfig is the mass and centre of gravity of what the arm carries. The centre of gravity is
+// relative to the tool flange.
+type PayloadConfig struct {
+	MassKg            float64   `json:"mass_kg"`
+	CenterOfGravityMM []float64 `json:"center_of_gravity_mm,omitempty"`
+}
+
+func (cfg *PayloadConfig) validate() error {
+	if cfg.MassKg < 0 {
+		return fmt.Errorf("payload mass_kg cannot be negative, got %f", cfg.MassKg)
+	}
+	if len(cfg.CenterOfGravityMM) != 0 && len(cfg.CenterOfGravityMM) != 3 {
+		return fmt.Errorf("payload center_of_gravity_mm must have 3 entries, got %d", len(cfg.CenterOfGravityMM))
+	}
+	return nil
+}
+
+func validateSensitivity(sensitivity int) error {
+	if sensitivity < 0 || sensitivity > 5 {
+		return fmt.Errorf("given collision sensitivity %d is invalid, must be 0-5", sensitivity)
+	}
+	return nil
+}
+
+// payloadFromExtra parses a `payload` override: a map with `mass_kg` and optionally
+// `center_of_gravity_mm`.
+func payloadFromExtra(val any) (*PayloadConfig, error) {
+	params, ok := val.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map with mass_kg, got %T", payloadKey, val)
+	}
+	mass, ok := params["mass_kg"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s.mass_kg must be a number", payloadKey)
+	}
+	p := &PayloadConfig{MassKg: mass}
+	if raw, ok := params["center_of_gravity_mm"]; ok {
+		list, ok := raw.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s.center_of_gravity_mm must be a list of numbers, got %T", payloadKey, raw)
+		}
+		for i, v := range list {
+			f, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s.center_of_gravity_mm[%d] is %T, not a number", payloadKey, i, v)
+			}
+			p.CenterOfGravityMM = append(p.CenterOfGravityMM, f)
+		}
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// moveOverridesFromExtra parses the overrides in extra into mo. Unlike the other move options, an
+// invalid override is an error rather than ignored: the move would otherwise run with a collision
+// sensitivity or payload the caller didn't ask for. An override of a setting the config doesn't set is
+// an error too, as there would be nothing to put back afterwards.
+func (x *xArm) moveOverridesFromExtra(mo *moveOptions, extra map[string]any) error {
+	if raw, ok := extra[collisionSensitivityKey]; ok {
+		v, isNum := f64(extra, collisionSensitivityKey)
+		switch {
+		case x.baselineSensitivity() == nil:
+			return fmt.Errorf("%s can only be overridden when it is configured", collisionSensitivityKey)
+		case !isNum || v != math.Trunc(v) || validateSensitivity(int(v)) != nil:
+			return fmt.Errorf("%s must be an integer 0-5, got %v", collisionSensitivityKey, raw)
+		}
+		s := int(v)
+		mo.sensitivity = &s
+	}
+	if raw, ok := extra[payloadKey]; ok {
+		if x.baselinePayload() == nil {
+			return fmt.Errorf("%s can only be overridden when it is configured", payloadKey)
+		}
+		p, err := payloadFromExtra(raw)
+		if err != nil {
+			return err
+		}
+		mo.payload = p
+	}
+	return nil
+}
+
+// overrideTracker numbers the moves that override settings, so only the latest one puts them back.
+type overrideTracker struct {
+	mu     sync.Mutex
+	latest uint64
+}
+
+func (t *overrideTracker) next() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.latest++
+	return t.latest
+}
+
+func (t *overrideTracker) isLatest(gen uint64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.latest == gen
+}
+
+// baselineSensitivity is the configured collision sensitivity, or nil if the config doesn't set it.
+func (x *xArm) baselineSensitivity() *int {
+	if x.conf == nil {
+		return nil
+	}
+	return x.conf.Sensitivity
+}
+
+// baselinePayload is the configured payload, or nil if the config doesn't set it.
+func (x *xArm) baselinePayload() *PayloadConfig {
+	if x.conf == nil {
+		return nil
+	}
+	return x.conf.Payload
+}
+
+// setPayload tells the controller the mass and centre of gravity of what the arm carries, which it
+// uses for collision detection and gravity compensation.
+func (x *xArm) setPayload(ctx context.Context, p PayloadConfig) error {
+	c := x.newCmd(regMap["LoadParam"])
+	c.params = binary.LittleEndian.AppendUint32(c.params, math.Float32bits(float32(p.MassKg)))
+	for i := range 3 {
+		var v float64
+		if i < len(p.CenterOfGravityMM) {
+			v = p.CenterOfGravityMM[i]
+		}
+		c.params = binary.LittleEndian.AppendUint32(c.params, math.Float32bits(float32(v)))
+	}
+	_, err := x.send(ctx, c, true)
+	return err
+}
+
+// applyMoveOverrides sends the move's overrides to the controller and returns the function that puts
+// the settings back. If it fails, whatever it had already changed has been put back.
+func (x *xArm) applyMoveOverrides(ctx context.Context, mo moveOptions) (func(), error) {
+	if mo.overridesErr != nil {
+		return nil, mo.overridesErr
+	}
+	if mo.sensitivity == nil && mo.payload == nil {
+		return func() {}, nil
+	}
+	gen := x.overrides.next()
+	// Both configured settings are put back, whichever this move changed, since an earlier move that
+	// was cut off may have left the other one overridden.
+	restore := func() {
+		if !x.overrides.isLatest(gen) {
+			// A later move has overridden the settings again and will put them back itself.
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), overrideRestoreTimeout)
+		defer cancel()
+		if s := x.baselineSensitivity(); s != nil {
+			if err := x.setCollisionDetectionSensitivity(ctx, *s); err != nil {
+				x.logger.Warnf("could not restore the collision sensitivity after a move: %v", err)
+			}
+		}
+		if p := x.baselinePayload(); p != nil {
+			if err := x.setPayload(ctx, *p); err != nil {
+				x.logger.Warnf("could not restore the payload after a move: %v", err)
+			}
+		}
+	}
+
+	if mo.sensitivity != nil {
+		if err := x.setCollisionDetectionSensitivity(ctx, *mo.sensitivity); err != nil {
+			restore()
+			return nil, fmt.Errorf("setting the move's collision sensitivity: %w", err)
+		}
+	}
+	if mo.payload != nil {
+		if err := x.setPayload(ctx, *mo.payload); err != nil {
+			restore()
+			return nil, fmt.Errorf("setting the move's payload: %w", err)
+		}
+	}
+	return restore, nil
+}
@@ -0,0 +1,77 @@
+package arm
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/test"
+)
+
+func TestPayloadFromExtra(t *testing.T) {
+	p, err := payloadFromExtra(map[string]any{"mass_kg": 1.5, "center_of_gravity_mm": []any{0.0, 0.0, 40.0}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, p.MassKg, test.ShouldEqual, 1.5)
+	test.That(t, p.CenterOfGravityMM, test.ShouldResemble, []float64{0, 0, 40})
+
+	p, err = payloadFromExtra(map[string]any{"mass_kg": 0.8})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, p.CenterOfGravityMM, test.ShouldBeNil)
+
+	_, err = payloadFromExtra(1.5)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = payloadFromExtra(map[string]any{"mass_kg": -1.0})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = payloadFromExtra(map[string]any{"mass_kg": 1.0, "center_of_gravity_mm": []any{0.0, 1.0}})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestMoveOverridesFromExtra(t *testing.T) {
+	x := &xArm{logger: logging.NewTestLogger(t), conf: &Config{}}
+	extra := map[string]any{collisionSensitivityKey: 5.0, payloadKey: map[string]any{"mass_kg": 2.0}}
+
+	// Without configured values there is nothing to put back, so the overrides are refused.
+	test.That(t, x.baselineSensitivity(), test.ShouldBeNil)
+	test.That(t, x.baselinePayload(), test.ShouldBeNil)
+	mo := x.moveOptions(nil, map[string]any{collisionSensitivityKey: 5.0})
+	test.That(t, mo.overridesErr, test.ShouldNotBeNil)
+	mo = x.moveOptions(nil, map[string]any{payloadKey: map[string]any{"mass_kg": 2.0}})
+	test.That(t, mo.overridesErr, test.ShouldNotBeNil)
+	_, err := x.applyMoveOverrides(context.Background(), mo)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	sensitivity := 1
+	x.conf = &Config{Sensitivity: &sensitivity, Payload: &PayloadConfig{MassKg: 0.5}}
+	test.That(t, *x.baselineSensitivity(), test.ShouldEqual, 1)
+	test.That(t, x.baselinePayload().MassKg, test.ShouldEqual, 0.5)
+
+	mo = x.moveOptions(nil, extra)
+	test.That(t, mo.overridesErr, test.ShouldBeNil)
+	test.That(t, *mo.sensitivity, test.ShouldEqual, 5)
+	test.That(t, mo.payload.MassKg, test.ShouldEqual, 2.0)
+
+	// Invalid overrides refuse the move, unlike other invalid options.
+	for _, bad := range []map[string]any{
+		{collisionSensitivityKey: 7.0},
+		{collisionSensitivityKey: 2.5},
+		{collisionSensitivityKey: "high"},
+		{payloadKey: "heavy"},
+	} {
+		mo = x.moveOptions(nil, bad)
+		test.That(t, mo.overridesErr, test.ShouldNotBeNil)
+	}
+
+	// The move is refused before it is sent to the controller.
+	err = x.MoveThroughJointPositions(context.Background(), nil, nil, map[string]any{payloadKey: "heavy"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, payloadKey)
+}
+
+func TestOverrideTracker(t *testing.T) {
+	var tr overrideTracker
+	first := tr.next()
+	test.That(t, tr.isLatest(first), test.ShouldBeTrue)
+	second := tr.next()
+	test.That(t, tr.isLatest(first), test.ShouldBeFalse)
+	test.That(t, tr.isLatest(second), test.ShouldBeTrue)
+}
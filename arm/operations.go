@@ -0,0 +1,342 @@
+package arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Moves with `waitAtEnd` set to false return before the arm stops. Each one is registered as an operation,
+// named by the caller's `operation_id` in `extra` or generated for it, and a background worker waits for the
+// controller's command queue to drain and the arm to stop before marking it done. Stop, a queue flush or a
+// new move that interrupts an operation marks it cancelled instead. Callers await or poll operations with
+// the `wait_for_operation` and `get_operation` DoCommands.
+
+const (
+	// DoCommand, extra and Status keys.
+	operationIDKey       = "operation_id"
+	waitForOperationKey  = "wait_for_operation"
+	getOperationKey      = "get_operation"
+	flushCommandQueueKey = "flush_command_queue"
+	operationKey         = "operation"
+	cmdCountKey          = "cmd_count"
+	pendingOperationsKey = "pending_operations"
+	lastOperationIDKey   = "last_operation_id"
+
+	operationStateRunning   = "running"
+	operationStateSucceeded = "succeeded"
+	operationStateFailed    = "failed"
+	operationStateCancelled = "cancelled"
+
+	// maxRetainedOperations bounds how many operations are remembered; running ones are never forgotten.
+	maxRetainedOperations = 64
+)
+
+// errOperationCancelled is wrapped by the error of every operation that was interrupted before the arm
+// finished it.
+var errOperationCancelled = errors.New("operation cancelled")
+
+// Why a running operation was cancelled.
+var (
+	errStoppedOperation    = fmt.Errorf("%w: the arm was stopped", errOperationCancelled)
+	errFlushedOperation    = fmt.Errorf("%w: the command queue was flushed", errOperationCancelled)
+	errSupersededOperation = fmt.Errorf("%w: a new move started", errOperationCancelled)
+)
+
+// moveOperation is one move that returned before the arm finished it.
+type moveOperation struct {
+	id      string
+	started time.Time
+	done    chan struct{}
+
+	// cancelled is closed, once, when the operation is interrupted; cause is written before that.
+	cancelled  chan struct{}
+	cancelOnce sync.Once
+	cause      error
+
+	// finished and err are only written once, before done is closed.
+	finished time.Time
+	err      error
+}
+
+// cancel interrupts the operation. Its worker stops waiting and finishes it with cause.
+func (op *moveOperation) cancel(cause error) {
+	op.cancelOnce.Do(func() {
+		op.cause = cause
+		close(op.cancelled)
+	})
+}
+
+func (op *moveOperation) finish(err error) {
+	op.err = err
+	op.finished = time.Now()
+	close(op.done)
+}
+
+func (op *moveOperation) isDone() bool {
+	select {
+	case <-op.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (op *moveOperation) toMap() map[string]any {
+	m := map[string]any{
+		operationIDKey: op.id,
+		"started":      op.started.Format(time.RFC3339Nano),
+		"state":        operationStateRunning,
+	}
+	if !op.isDone() {
+		return m
+	}
+	m["finished"] = op.finished.Format(time.RFC3339Nano)
+	m["duration_sec"] = op.finished.Sub(op.started).Seconds()
+	switch {
+	case errors.Is(op.err, errOperationCancelled):
+		m["state"] = operationStateCancelled
+		m["error"] = op.err.Error()
+	case op.err != nil:
+		m["state"] = operationStateFailed
+		m["error"] = op.err.Error()
+		if ce := controllerErrorMap(op.err); ce != nil {
+			m[controllerErrorKey] = ce
+		}
+	default:
+		m["state"] = operationStateSucceeded
+	}
+	return m
+}
+
+// operationTracker remembers running operations and the most recent finished ones.
+type operationTracker struct {
+	mu     sync.Mutex
+	ops    map[string]*moveOperation
+	order  []string // ids, oldest first
+	nextID int
+}
+
+// begin registers a new running operation. An empty id is replaced by a generated one.
+func (t *operationTracker) begin(id string) (*moveOperation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ops == nil {
+		t.ops = map[string]*moveOperation{}
+	}
+	if id == "" {
+		t.nextID++
+		id = fmt.Sprintf("move-%d", t.nextID)
+	}
+	if existing, ok := t.ops[id]; ok && !existing.isDone() {
+		return nil, fmt.Errorf("operation %q is still running", id)
+	}
+
+	op := &moveOperation{id: id, started: time.Now(), done: make(chan struct{}), cancelled: make(chan struct{})}
+	if _, ok := t.ops[id]; ok {
+		t.removeLocked(id)
+	}
+	t.ops[id] = op
+	t.order = append(t.order, id)
+
+	// Forget the oldest finished operations once there are too many.
+	for i := 0; len(t.order) > maxRetainedOperations && i < len(t.order); {
+		if t.ops[t.order[i]].isDone() {
+			t.removeLocked(t.order[i])
+		} else {
+			i++
+		}
+	}
+	return op, nil
+}
+
+func (t *operationTracker) removeLocked(id string) {
+	delete(t.ops, id)
+	for i, o := range t.order {
+		if o == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// get returns the operation with the given id, or the most recent one if id is empty.
+func (t *operationTracker) get(id string) (*moveOperation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if id == "" {
+		if len(t.order) == 0 {
+			return nil, errors.New("no operations have been started")
+		}
+		id = t.order[len(t.order)-1]
+	}
+	op, ok := t.ops[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", id)
+	}
+	return op, nil
+}
+
+// pending returns the ids of operations still running, oldest first.
+func (t *operationTracker) pending() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := []string{}
+	for _, id := range t.order {
+		if !t.ops[id].isDone() {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// cancelPending cancels every running operation with cause and returns them.
+func (t *operationTracker) cancelPending(cause error) []*moveOperation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var ops []*moveOperation
+	for _, id := range t.order {
+		if op := t.ops[id]; !op.isDone() {
+			op.cancel(cause)
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func (t *operationTracker) last() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.order) == 0 {
+		return ""
+	}
+	return t.order[len(t.order)-1]
+}
+
+// runOperation runs the rest of op's move, then after, and finishes op. It runs on the arm's background
+// workers, so it outlives the call that started the move and is cancelled when the arm is closed or the
+// operation is.
+func (x *xArm) runOperation(op *moveOperation, run func(context.Context) error, after func()) {
+	if x.workers == nil {
+		after()
+		op.finish(errors.New("arm has no background workers to track the move"))
+		return
+	}
+	x.workers.Add(func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-op.cancelled:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		err := run(ctx)
+		after()
+		select {
+		case <-op.cancelled:
+			err = op.cause
+		default:
+		}
+		op.finish(err)
+	})
+}
+
+// awaitMotionStop finishes op once the controller's command queue has drained and the arm has stopped.
+func (x *xArm) awaitMotionStop(op *moveOperation, after func()) {
+	x.runOperation(op, func(ctx context.Context) error { return x.waitForQueuedMotion(ctx, x.moveHZ) }, after)
+}
+
+// waitForOperations waits for ops, just cancelled, to finish, so each has put back its move's
+// overrides before the arm does anything else.
+func waitForOperations(ctx context.Context, ops []*moveOperation) error {
+	for _, op := range ops {
+		select {
+		case <-op.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// flushCommandQueue stops the arm and discards every motion command queued on the controller, then puts
+// the arm back in the motion mode it was in (position, servo or manual), ready for new commands. An arm
+// that wasn't started is left for the next move to start.
+func (x *xArm) flushCommandQueue(ctx context.Context) error {
+	ctx, done := x.opMgr.New(ctx)
+	defer done()
+
+	ops := x.ops.cancelPending(errFlushedOperation)
+	mode := x.started.Swap(-1)
+	// State 4 stops the arm and clears the controller's command cache.
+	if err := x.setMotionState(ctx, 4); err != nil {
+		return err
+	}
+	if err := waitForOperations(ctx, ops); err != nil {
+		return err
+	}
+	switch mode {
+	case -1:
+		return nil
+	case manualMode:
+		// Manual mode can't be entered straight from servo mode, so it has its own path.
+		return x.enterManualMode(ctx)
+	default:
+		return x.start(ctx, mode == 0)
+	}
+}
+
+func operationIDFromCmd(val any) (string, error) {
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case bool:
+		// `true` selects the most recent operation.
+		return "", nil
+	case map[string]any:
+		if raw, ok := v[operationIDKey]; ok {
+			id, ok := raw.(string)
+			if !ok {
+				return "", fmt.Errorf("%s must be a string, got %T", operationIDKey, raw)
+			}
+			return id, nil
+		}
+		return "", nil
+	default:
+		return "", fmt.Errorf("expected an operation id, got %T", val)
+	}
+}
+
+// waitForOperationCommand handles the `wait_for_operation` DoCommand. It takes an operation id, or a map
+// with `operation_id` and an optional `timeout_sec`, and returns the operation once it is done.
+func (x *xArm) waitForOperationCommand(ctx context.Context, val any) (map[string]any, error) {
+	id, err := operationIDFromCmd(val)
+	if err != nil {
+		return nil, err
+	}
+	op, err := x.ops.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if params, ok := val.(map[string]any); ok {
+		if raw, ok := params["timeout_sec"]; ok {
+			timeout, ok := raw.(float64)
+			if !ok || timeout <= 0 {
+				return nil, fmt.Errorf("timeout_sec must be a positive number, got %v", raw)
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout*float64(time.Second)))
+			defer cancel()
+		}
+	}
+	select {
+	case <-op.done:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for operation %q: %w", op.id, ctx.Err())
+	}
+	return op.toMap(), nil
+}
@@ -0,0 +1,255 @@
+package arm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/operation"
+	"go.viam.com/test"
+	goutils "go.viam.com/utils"
+)
+
+func TestOperationTracker(t *testing.T) {
+	var tr operationTracker
+
+	_, err := tr.get("")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	first, err := tr.begin("")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, first.id, test.ShouldEqual, "move-1")
+	named, err := tr.begin("pick")
+	test.That(t, err, test.ShouldBeNil)
+
+	// A running id can't be reused, but the most recent operation can be looked up without one.
+	_, err = tr.begin("pick")
+	test.That(t, err, test.ShouldNotBeNil)
+	op, err := tr.get("")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, op, test.ShouldEqual, named)
+	test.That(t, tr.pending(), test.ShouldResemble, []string{"move-1", "pick"})
+	test.That(t, named.toMap()["state"], test.ShouldEqual, operationStateRunning)
+
+	first.finish(nil)
+	named.finish(errors.New("collision"))
+	test.That(t, tr.pending(), test.ShouldResemble, []string{})
+	test.That(t, first.toMap()["state"], test.ShouldEqual, operationStateSucceeded)
+	test.That(t, named.toMap()["state"], test.ShouldEqual, operationStateFailed)
+	test.That(t, named.toMap()["error"], test.ShouldEqual, "collision")
+
+	// A finished id may be reused, and replaces the old operation.
+	again, err := tr.begin("pick")
+	test.That(t, err, test.ShouldBeNil)
+	op, err = tr.get("pick")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, op, test.ShouldEqual, again)
+	test.That(t, tr.last(), test.ShouldEqual, "pick")
+
+	// Old finished operations are forgotten, running ones are kept.
+	for i := range maxRetainedOperations + 10 {
+		op, err := tr.begin(fmt.Sprintf("op-%d", i))
+		test.That(t, err, test.ShouldBeNil)
+		op.finish(nil)
+	}
+	test.That(t, len(tr.order), test.ShouldEqual, maxRetainedOperations)
+	_, err = tr.get("pick")
+	test.That(t, err, test.ShouldBeNil)
+	_, err = tr.get("move-1")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestWaitForOperationCommand(t *testing.T) {
+	x := &xArm{}
+	op, err := x.ops.begin("place")
+	test.That(t, err, test.ShouldBeNil)
+
+	_, err = x.waitForOperationCommand(context.Background(), map[string]any{operationIDKey: "place", "timeout_sec": 0.01})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	op.finish(nil)
+	resp, err := x.waitForOperationCommand(context.Background(), "place")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["state"], test.ShouldEqual, operationStateSucceeded)
+
+	resp, err = x.waitForOperationCommand(context.Background(), true)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp[operationIDKey], test.ShouldEqual, "place")
+
+	_, err = x.waitForOperationCommand(context.Background(), "unknown")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = x.waitForOperationCommand(context.Background(), 3.0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestCancelOperations(t *testing.T) {
+	x := &xArm{workers: goutils.NewBackgroundStoppableWorkers()}
+	defer x.workers.Stop()
+
+	op, err := x.ops.begin("place")
+	test.That(t, err, test.ShouldBeNil)
+	restored := false
+	x.runOperation(op, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, func() { restored = true })
+
+	ops := x.ops.cancelPending(errStoppedOperation)
+	test.That(t, ops, test.ShouldResemble, []*moveOperation{op})
+	test.That(t, waitForOperations(context.Background(), ops), test.ShouldBeNil)
+	test.That(t, restored, test.ShouldBeTrue)
+	test.That(t, errors.Is(op.err, errOperationCancelled), test.ShouldBeTrue)
+	test.That(t, op.toMap()["state"], test.ShouldEqual, operationStateCancelled)
+	test.That(t, op.toMap()["error"], test.ShouldEqual, errStoppedOperation.Error())
+
+	// An operation that already finished is left alone.
+	done, err := x.ops.begin("")
+	test.That(t, err, test.ShouldBeNil)
+	x.runOperation(done, func(context.Context) error { return nil }, func() {})
+	test.That(t, waitForOperations(context.Background(), []*moveOperation{done}), test.ShouldBeNil)
+	test.That(t, x.ops.cancelPending(errSupersededOperation), test.ShouldBeEmpty)
+	test.That(t, done.toMap()["state"], test.ShouldEqual, operationStateSucceeded)
+}
+
+// fakeArmController stands in for the controller's command port. It accepts every command, reports
+// warnCode until a ClearWarn, reads the joints at joints, and records the commands it gets, the
+// motion modes it is put in and each switch of reduced mode.
+type fakeArmController struct {
+	mu       sync.Mutex
+	warnCode byte
+	joints   [maxControllerJoints]float32
+	regs     []byte
+	modes    []byte
+	reduced  []bool
+}
+
+func startFakeArmController(t *testing.T, c *fakeArmController) string {
+	t.Helper()
+	var lc net.ListenConfig
+	ln, err := lc.Listen(context.Background(), "tcp", "127.0.0.1:0")
+	test.That(t, err, test.ShouldBeNil)
+	t.Cleanup(func() { test.That(t, ln.Close(), test.ShouldBeNil) })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go c.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (c *fakeArmController) serve(conn net.Conn) {
+	defer conn.Close() //nolint:errcheck
+	for {
+		header := make([]byte, 7)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		params := make([]byte, binary.BigEndian.Uint16(header[4:6])-1)
+		if _, err := io.ReadFull(conn, params); err != nil {
+			return
+		}
+		c.mu.Lock()
+		c.regs = append(c.regs, header[6])
+		// A zero state byte, plus the error and warning codes for GetError.
+		resp := []byte{0}
+		switch header[6] {
+		case regMap["GetError"]:
+			resp = []byte{0, 0, c.warnCode}
+			if c.warnCode != 0 {
+				resp[0] = warningState
+			}
+		case regMap["ClearWarn"]:
+			c.warnCode = 0
+		case regMap["JointPos"]:
+			for _, j := range c.joints {
+				resp = binary.LittleEndian.AppendUint32(resp, math.Float32bits(j))
+			}
+		case regMap["SetMode"]:
+			c.modes = append(c.modes, params[0])
+		case regMap["SetReduced"]:
+			c.reduced = append(c.reduced, params[0] == 1)
+		}
+		c.mu.Unlock()
+		binary.BigEndian.PutUint16(header[4:6], uint16(len(resp)+1)) //nolint:gosec
+		if _, err := conn.Write(append(header, resp...)); err != nil {
+			return
+		}
+	}
+}
+
+func (c *fakeArmController) sent(reg byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Contains(c.regs, reg)
+}
+
+func (c *fakeArmController) reducedSwitches() []bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]bool(nil), c.reduced...)
+}
+
+func (c *fakeArmController) setModes() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]byte(nil), c.modes...)
+}
+
+func TestFlushCommandQueue(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		started int32
+		modes   []byte
+	}{
+		{"not started", -1, nil},
+		{"position", 0, []byte{0}},
+		{"servo", servoMotionMode, []byte{servoMotionMode}},
+		// Manual mode goes through position mode, as entering it does.
+		{"manual", manualMode, []byte{0, manualMode}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logging.NewTestLogger(t)
+			c := &fakeArmController{}
+			x := &xArm{
+				logger:  logger,
+				cmdConn: newModbusConn(startFakeArmController(t, c), logger, nil),
+				opMgr:   operation.NewSingleOperationManager(),
+			}
+			x.started.Store(tc.started)
+
+			test.That(t, x.flushCommandQueue(context.Background()), test.ShouldBeNil)
+			test.That(t, c.setModes(), test.ShouldResemble, tc.modes)
+			test.That(t, x.started.Load(), test.ShouldEqual, tc.started)
+		})
+	}
+}
+
+func TestStatusWithoutController(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	x := &xArm{logger: logger, cmdConn: newModbusConn("", logger, nil)}
+	x.closed.Store(true)
+	_, err := x.ops.begin("place")
+	test.That(t, err, test.ShouldBeNil)
+
+	// The module's own state is still reported when the controller can't be read.
+	status, err := x.Status(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, status[pendingOperationsKey], test.ShouldResemble, []any{"place"})
+	test.That(t, status[lastOperationIDKey], test.ShouldEqual, "place")
+	test.That(t, status[reducedModeKey], test.ShouldEqual, false)
+	test.That(t, status[statusErrorKey], test.ShouldContainSubstring, "closed")
+	test.That(t, status, test.ShouldNotContainKey, cmdCountKey)
+	test.That(t, status, test.ShouldNotContainKey, servoErrorsKey)
+}
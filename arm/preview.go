@@ -0,0 +1,335 @@
+package arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+)
+
+// Plan previews run a move through exactly the planner a real move would use (planJointSteps) and report
+// what would be sent to the arm, without sending it. They are the `plan_only` DoCommand and `extra` key.
+
+const (
+	// previewLimitTolerance is how far past a limit a sample may go before it is reported, to absorb
+	// the discretization of finite differences at moveHZ.
+	previewLimitTolerance = 0.02
+	// maxPreviewViolations caps the number of violations listed; violationCount still counts them all.
+	maxPreviewViolations = 100
+
+	violationPosition     = "position"
+	violationWaypoint     = "waypoint"
+	violationVelocity     = "velocity"
+	violationAcceleration = "acceleration"
+)
+
+// previewViolation is one sample at which a joint exceeds one of its limits. For violationWaypoint,
+// sample is instead the index of the requested waypoint that is out of bounds.
+type previewViolation struct {
+	sample int
+	joint  int
+	kind   string
+	value  float64 // radians, rad/s or rad/s²
+	limit  float64
+}
+
+// movePreview is the outcome of planning a move without executing it.
+type movePreview struct {
+	planner string
+	start   []referenceframe.Input
+	steps   [][]referenceframe.Input
+	hz      float64
+	// timed is false when the controller times the move itself, in which case there are no samples to
+	// take velocities and accelerations from.
+	timed bool
+
+	peakVel        []float64
+	peakAcc        []float64
+	violations     []previewViolation
+	violationCount int
+}
+
+func (p *movePreview) duration() float64 {
+	if !p.timed {
+		return 0
+	}
+	return float64(len(p.steps)) / p.hz
+}
+
+func (p *movePreview) addViolation(v previewViolation) {
+	p.violationCount++
+	if len(p.violations) < maxPreviewViolations {
+		p.violations = append(p.violations, v)
+	}
+}
+
+// violationsErr summarizes the violations as an error, or returns nil if there are none.
+func (p *movePreview) violationsErr() error {
+	if p.violationCount == 0 {
+		return nil
+	}
+	v := p.violations[0]
+	return fmt.Errorf("planned move has %d limit violations, first is joint %d %s at sample %d (%.4f, limit %.4f)",
+		p.violationCount, v.joint, v.kind, v.sample, v.value, v.limit)
+}
+
+// previewMove plans a move through `positions` from `start`, or from the arm's current position if start
+// is nil, and checks every planned sample against the joint, velocity and acceleration limits.
+func (x *xArm) previewMove(
+	ctx context.Context,
+	start []referenceframe.Input,
+	positions [][]referenceframe.Input,
+	mo moveOptions,
+) (*movePreview, error) {
+	if len(positions) == 0 {
+		return nil, errors.New("no positions to plan through")
+	}
+	if start == nil {
+		// A plain read: JointPositions would clear controller errors, which a dry run must not do.
+		var err error
+		if start, err = x.readJointPositions(ctx, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, pos := range positions {
+		if len(pos) != len(start) {
+			return nil, fmt.Errorf("planned positions must have %d joints, got %d", len(start), len(pos))
+		}
+	}
+
+	positions, err := resolveCableWraps(x.cableRanges, start, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	// A real move refuses out of bounds waypoints before planning, and so does the preview.
+	p := &movePreview{start: start, hz: mo.moveHZ}
+	if x.model != nil {
+		limits := x.model.DoF()
+		for i, pos := range positions {
+			for j := 0; j < len(pos) && j < len(limits); j++ {
+				if pos[j] < limits[j].Min || pos[j] > limits[j].Max {
+					p.addViolation(previewViolation{sample: i, joint: j, kind: violationWaypoint, value: pos[j], limit: nearest(pos[j], limits[j])})
+				}
+			}
+		}
+	}
+	if p.violationCount > 0 {
+		return p, nil
+	}
+
+	steps, planner, err := x.planJointSteps(ctx, start, positions, mo)
+	if err != nil {
+		return nil, err
+	}
+	p.steps, p.planner, p.timed = steps, planner, planner != plannerController
+	x.checkPreviewLimits(p, mo)
+	return p, nil
+}
+
+// estimateMoveDuration plans a move from the arm's current position through positions, without sending
+// it, and returns how long it will take. A move the controller times itself is estimated from the
+// largest joint travel to each waypoint at the move's speed.
+func (x *xArm) estimateMoveDuration(ctx context.Context, positions [][]referenceframe.Input, mo moveOptions) (time.Duration, error) {
+	p, err := x.previewMove(ctx, nil, positions, mo)
+	if err != nil {
+		return 0, err
+	}
+	if err := p.violationsErr(); err != nil {
+		return 0, err
+	}
+	if p.timed {
+		return time.Duration(p.duration() * float64(time.Second)), nil
+	}
+	return controllerMoveDuration(p.start, positions, mo.speed), nil
+}
+
+// controllerMoveDuration estimates a controller-timed move through positions from start, with every
+// waypoint taking as long as its largest joint travel at speed.
+func controllerMoveDuration(start []referenceframe.Input, positions [][]referenceframe.Input, speed float64) time.Duration {
+	if speed <= 0 {
+		return 0
+	}
+	var secs float64
+	prev := start
+	for _, pos := range positions {
+		var travel float64
+		for j := 0; j < len(pos) && j < len(prev); j++ {
+			travel = math.Max(travel, math.Abs(pos[j]-prev[j]))
+		}
+		secs += travel / speed
+		prev = pos
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+func (x *xArm) checkPreviewLimits(p *movePreview, mo moveOptions) {
+	dof := len(p.start)
+	var limits []referenceframe.Limit
+	if x.model != nil {
+		limits = x.model.DoF()
+	}
+	for i, step := range p.steps {
+		for j := 0; j < dof && j < len(limits); j++ {
+			if step[j] < limits[j].Min || step[j] > limits[j].Max {
+				p.addViolation(previewViolation{sample: i, joint: j, kind: violationPosition, value: step[j], limit: nearest(step[j], limits[j])})
+			}
+		}
+	}
+	if !p.timed {
+		return
+	}
+
+	velLimits, accelLimits := x.jointMotionLimits(mo, dof)
+	p.peakVel = make([]float64, dof)
+	p.peakAcc = make([]float64, dof)
+	// Treat the arm as resting at the start before the first sample, as it is on a real move.
+	prevPos := p.start
+	prevVel := make([]float64, dof)
+	for i, step := range p.steps {
+		vel := make([]float64, dof)
+		for j := range dof {
+			vel[j] = (step[j] - prevPos[j]) * p.hz
+			acc := (vel[j] - prevVel[j]) * p.hz
+			p.peakVel[j] = math.Max(p.peakVel[j], math.Abs(vel[j]))
+			p.peakAcc[j] = math.Max(p.peakAcc[j], math.Abs(acc))
+			if math.Abs(vel[j]) > velLimits[j]*(1+previewLimitTolerance) {
+				p.addViolation(previewViolation{sample: i, joint: j, kind: violationVelocity, value: vel[j], limit: velLimits[j]})
+			}
+			if math.Abs(acc) > accelLimits[j]*(1+previewLimitTolerance) {
+				p.addViolation(previewViolation{sample: i, joint: j, kind: violationAcceleration, value: acc, limit: accelLimits[j]})
+			}
+		}
+		prevPos, prevVel = step, vel
+	}
+}
+
+// previewToMap converts the preview into a DoCommand response, in degrees and millimetres. The TCP path is
+// only included when the arm's kinematic model is available.
+func (x *xArm) previewToMap(p *movePreview) (map[string]any, error) {
+	samples := make([]any, len(p.steps))
+	for i, step := range p.steps {
+		samples[i] = degreesList(step)
+	}
+	out := map[string]any{
+		"planner":              p.planner,
+		"controller_timed":     !p.timed,
+		"start_degs":           degreesList(p.start),
+		"joint_positions_degs": samples,
+		"sample_count":         float64(len(p.steps)),
+		"sample_period_sec":    1 / p.hz,
+		"duration_sec":         p.duration(),
+		"violation_count":      float64(p.violationCount),
+	}
+	if p.timed {
+		out["peak_joint_velocities_degs_per_sec"] = degreesList(p.peakVel)
+		out["peak_joint_accelerations_degs_per_sec_per_sec"] = degreesList(p.peakAcc)
+	}
+
+	violations := make([]any, len(p.violations))
+	for i, v := range p.violations {
+		violations[i] = map[string]any{
+			"sample": float64(v.sample),
+			"joint":  float64(v.joint),
+			"kind":   v.kind,
+			"value":  utils.RadToDeg(v.value),
+			"limit":  utils.RadToDeg(v.limit),
+		}
+	}
+	out["violations"] = violations
+
+	if x.model != nil {
+		path := make([]any, len(p.steps))
+		for i, step := range p.steps {
+			pose, err := x.model.Transform(step)
+			if err != nil {
+				return nil, err
+			}
+			pt := pose.Point()
+			ov := pose.Orientation().OrientationVectorDegrees()
+			path[i] = map[string]any{
+				"x": pt.X, "y": pt.Y, "z": pt.Z,
+				"o_x": ov.OX, "o_y": ov.OY, "o_z": ov.OZ, "theta": ov.Theta,
+			}
+		}
+		out["tcp_path"] = path
+	}
+	return out, nil
+}
+
+// planOnlyCommand handles the `plan_only` DoCommand. `positions_degs` is the list of waypoints to plan
+// through; `start_degs` optionally replaces the arm's current position as the start; and `options`
+// takes the same keys as `extra` on MoveThroughJointPositions, such as `speed_d` or `motion_profile`.
+func (x *xArm) planOnlyCommand(ctx context.Context, val any) (map[string]any, error) {
+	params, ok := val.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map with key positions_degs; got %T", planOnlyKey, val)
+	}
+	rawPositions, ok := params["positions_degs"].([]any)
+	if !ok || len(rawPositions) == 0 {
+		return nil, fmt.Errorf("%s.positions_degs must be a non-empty list of joint positions", planOnlyKey)
+	}
+	positions := make([][]referenceframe.Input, len(rawPositions))
+	for i, raw := range rawPositions {
+		pos, err := radiansFromDegreesList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s.positions_degs[%d]: %w", planOnlyKey, i, err)
+		}
+		positions[i] = pos
+	}
+	var start []referenceframe.Input
+	if raw, ok := params["start_degs"]; ok {
+		var err error
+		if start, err = radiansFromDegreesList(raw); err != nil {
+			return nil, fmt.Errorf("%s.start_degs: %w", planOnlyKey, err)
+		}
+	}
+	var extra map[string]any
+	if raw, ok := params["options"]; ok {
+		if extra, ok = raw.(map[string]any); !ok {
+			return nil, fmt.Errorf("%s.options must be a map; got %T", planOnlyKey, raw)
+		}
+	}
+
+	p, err := x.previewMove(ctx, start, positions, x.moveOptions(nil, extra))
+	if err != nil {
+		return nil, err
+	}
+	return x.previewToMap(p)
+}
+
+// nearest returns whichever bound of the limit is closer to v.
+func nearest(v float64, limit referenceframe.Limit) float64 {
+	if math.Abs(v-limit.Min) < math.Abs(v-limit.Max) {
+		return limit.Min
+	}
+	return limit.Max
+}
+
+func degreesList(rads []float64) []any {
+	out := make([]any, len(rads))
+	for i, r := range rads {
+		out[i] = utils.RadToDeg(r)
+	}
+	return out
+}
+
+func radiansFromDegreesList(val any) ([]referenceframe.Input, error) {
+	list, ok := val.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a list of numbers, got %T", val)
+	}
+	out := make([]referenceframe.Input, len(list))
+	for i, v := range list {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("element %d is %T, not a number", i, v)
+		}
+		out[i] = utils.DegToRad(f)
+	}
+	return out, nil
+}
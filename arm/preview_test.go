@@ -0,0 +1,111 @@
+package arm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/test"
+)
+
+func TestPreviewMove(t *testing.T) {
+	var err error
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	x := &xArm{
+		speed:        utils.DegToRad(defaultSpeed),
+		acceleration: utils.DegToRad(defaultAccel),
+		moveHZ:       defaultMoveHz,
+		jerk:         utils.DegToRad(defaultJerk),
+		logger:       logger,
+	}
+	start := []float64{0, 0, 0, 0, 0, 0}
+	x.model, err = MakeModelFrame("", ModelName6DOF, nil, start, false, nil, logger, 0)
+	test.That(t, err, test.ShouldBeNil)
+
+	goal := []float64{0.5, 0, 0, 0, 0, 0.5}
+	p, err := x.previewMove(ctx, start, [][]float64{goal}, x.moveOptions(nil, map[string]any{"motion_profile": "s_curve"}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, p.planner, test.ShouldEqual, plannerSCurve)
+	test.That(t, p.steps[len(p.steps)-1], test.ShouldResemble, goal)
+	test.That(t, p.violationCount, test.ShouldEqual, 0)
+	test.That(t, p.peakVel[0], test.ShouldBeLessThanOrEqualTo, x.speed*(1+previewLimitTolerance))
+	test.That(t, p.peakVel[1], test.ShouldEqual, 0)
+
+	out, err := x.previewToMap(p)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out["sample_count"], test.ShouldEqual, float64(len(p.steps)))
+	test.That(t, len(out["tcp_path"].([]any)), test.ShouldEqual, len(p.steps))
+	test.That(t, out["duration_sec"], test.ShouldAlmostEqual, float64(len(p.steps))/defaultMoveHz)
+
+	// Planning to a waypoint past a joint limit reports it rather than failing.
+	p, err = x.previewMove(ctx, start, [][]float64{goal, {0, 0, 0, 0, 0, 10}}, x.moveOptions(nil, nil))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, p.violationCount, test.ShouldEqual, 1)
+	test.That(t, p.violations[0], test.ShouldResemble, previewViolation{
+		sample: 1, joint: 5, kind: violationWaypoint, value: 10, limit: x.model.DoF()[5].Max,
+	})
+	test.That(t, p.steps, test.ShouldBeNil)
+	test.That(t, p.violationsErr(), test.ShouldNotBeNil)
+
+	// Direct moves are timed by the controller, so only the goal itself is planned.
+	p, err = x.previewMove(ctx, start, [][]float64{goal}, x.moveOptions(nil, map[string]any{"direct": true}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, p.planner, test.ShouldEqual, plannerController)
+	test.That(t, p.steps, test.ShouldResemble, [][]float64{goal})
+	test.That(t, p.duration(), test.ShouldEqual, 0)
+
+	// They may also pass through several waypoints, which are queued on the controller as given.
+	mid := []float64{0.25, 0, 0, 0, 0, 0}
+	p, err = x.previewMove(ctx, start, [][]float64{mid, goal}, x.moveOptions(nil, map[string]any{"direct": true}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, p.steps, test.ShouldResemble, [][]float64{mid, goal})
+}
+
+func TestPlanOnlyCommand(t *testing.T) {
+	var err error
+	logger := logging.NewTestLogger(t)
+
+	x := &xArm{
+		speed:        utils.DegToRad(defaultSpeed),
+		acceleration: utils.DegToRad(defaultAccel),
+		moveHZ:       defaultMoveHz,
+		logger:       logger,
+	}
+	x.model, err = MakeModelFrame("", ModelName6DOF, nil, nil, false, nil, logger, 0)
+	test.That(t, err, test.ShouldBeNil)
+
+	resp, err := x.DoCommand(context.Background(), map[string]any{planOnlyKey: map[string]any{
+		"start_degs":     []any{0., 0., 0., 0., 0., 0.},
+		"positions_degs": []any{[]any{30., 0., 0., 0., 0., 0.}, []any{30., 10., 0., 0., 0., 0.}},
+		"options":        map[string]any{"speed_d": 30.},
+	}})
+	test.That(t, err, test.ShouldBeNil)
+	plan := resp[planOnlyKey].(map[string]any)
+	test.That(t, plan["planner"], test.ShouldEqual, plannerTrapezoidal)
+	samples := plan["joint_positions_degs"].([]any)
+	last := samples[len(samples)-1].([]any)
+	// The trapezoid's discretization lands within a step of the goal, and the arm settles onto it.
+	test.That(t, last[0], test.ShouldAlmostEqual, 30., 0.5)
+	test.That(t, last[1], test.ShouldAlmostEqual, 10., 0.5)
+	test.That(t, plan["peak_joint_velocities_degs_per_sec"].([]any)[0], test.ShouldBeLessThanOrEqualTo, 30*(1+previewLimitTolerance))
+
+	_, err = x.DoCommand(context.Background(), map[string]any{planOnlyKey: map[string]any{"positions_degs": []any{}}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = x.DoCommand(context.Background(), map[string]any{planOnlyKey: map[string]any{
+		"start_degs": []any{0., 0.}, "positions_degs": []any{[]any{"a", 0.}},
+	}})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestControllerMoveDuration(t *testing.T) {
+	start := []referenceframe.Input{0, 0}
+	positions := [][]referenceframe.Input{{1, 0.5}, {1, -1}}
+	// The largest travel to each waypoint at 0.5 rad/s: 1 rad, then 1.5 rad.
+	test.That(t, controllerMoveDuration(start, positions, 0.5), test.ShouldEqual, 5*time.Second)
+	test.That(t, controllerMoveDuration(start, positions, 0), test.ShouldEqual, time.Duration(0))
+}
@@ -0,0 +1,332 @@
+package arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+// Before every move and joint read, checkReadyState clears whatever error or warning the controller
+// reports. Without an `error_recovery` config it clears everything, as it always has. With one, only the
+// configured kinds of error are cleared automatically, at most a set number of times per window, and
+// the arm can be made to home or wait for an operator before it moves again. An operator clears
+// everything, and resets the policy, with the `clear_error` DoCommand.
+
+const (
+	afterRecoveryContinue    = "continue"
+	afterRecoveryHome        = "home"
+	afterRecoveryAcknowledge = "acknowledge"
+
+	getRecoveryStatusKey = "get_recovery_status"
+
+	// maxRecoveryEvents bounds how many recoveries get_recovery_status lists.
+	maxRecoveryEvents = 32
+
+	recoveryActionCleared = "cleared"
+	recoveryActionRefused = "refused"
+)
+
+// ErrorRecoveryConfig controls which controller errors the driver may clear on its own.
+type ErrorRecoveryConfig struct {
+	// AutoClear lists the ControllerErrorKinds that may be cleared automatically. It defaults to the
+	// kinds whose errors are Recoverable.
+	AutoClear []string `json:"auto_clear,omitempty"`
+	// MaxAutoClears is how many errors may be cleared automatically within WindowSec before an
+	// operator has to step in. 0 means no limit.
+	MaxAutoClears int     `json:"max_auto_clears,omitempty"`
+	WindowSec     float64 `json:"window_sec,omitempty"`
+	// ReenableServos is whether the servos may be turned back on after an automatic recovery. When
+	// false they stay off, and the arm waits for an operator to run clear_error. Defaults to true.
+	ReenableServos *bool `json:"reenable_servos,omitempty"`
+	// AfterRecovery is what happens before the next move after an automatic recovery: "continue"
+	// (the default), "home" to move every joint to zero first, or "acknowledge" to refuse moves
+	// until an operator runs clear_error.
+	AfterRecovery string `json:"after_recovery,omitempty"`
+}
+
+var controllerErrorKindNames = map[ControllerErrorKind]bool{
+	ErrorKindEStop: true, ErrorKindCollision: true, ErrorKindServo: true, ErrorKindJointLimit: true,
+	ErrorKindSafetyBoundary: true, ErrorKindManualMode: true, ErrorKindCommunication: true,
+	ErrorKindFTOverload: true, ErrorKindFTSensor: true, ErrorKindMotion: true, ErrorKindWarning: true,
+	ErrorKindUnknown: true,
+}
+
+func (cfg *ErrorRecoveryConfig) validate() error {
+	for i, k := range cfg.AutoClear {
+		if !controllerErrorKindNames[ControllerErrorKind(k)] {
+			return fmt.Errorf("error_recovery auto_clear[%d] %q is not an error kind", i, k)
+		}
+	}
+	if cfg.MaxAutoClears < 0 {
+		return fmt.Errorf("error_recovery max_auto_clears cannot be negative, got %d", cfg.MaxAutoClears)
+	}
+	if cfg.WindowSec < 0 {
+		return fmt.Errorf("error_recovery window_sec cannot be negative, got %f", cfg.WindowSec)
+	}
+	if cfg.MaxAutoClears > 0 && cfg.WindowSec == 0 {
+		return errors.New("error_recovery max_auto_clears needs a window_sec")
+	}
+	switch cfg.AfterRecovery {
+	case "", afterRecoveryContinue, afterRecoveryHome, afterRecoveryAcknowledge:
+	default:
+		return fmt.Errorf("error_recovery after_recovery must be %q, %q or %q, got %q",
+			afterRecoveryContinue, afterRecoveryHome, afterRecoveryAcknowledge, cfg.AfterRecovery)
+	}
+	return nil
+}
+
+// recoveryPolicy is the parsed ErrorRecoveryConfig.
+type recoveryPolicy struct {
+	autoClear     map[ControllerErrorKind]bool // nil allows every Recoverable kind
+	maxAutoClears int
+	window        time.Duration
+	afterRecovery string
+	holdServos    bool // servos stay off after an automatic recovery until an operator clears
+}
+
+func newRecoveryPolicy(cfg *ErrorRecoveryConfig) *recoveryPolicy {
+	if cfg == nil {
+		return nil
+	}
+	p := &recoveryPolicy{
+		maxAutoClears: cfg.MaxAutoClears,
+		window:        time.Duration(cfg.WindowSec * float64(time.Second)),
+		afterRecovery: cfg.AfterRecovery,
+		holdServos:    cfg.ReenableServos != nil && !*cfg.ReenableServos,
+	}
+	if len(cfg.AutoClear) > 0 {
+		p.autoClear = map[ControllerErrorKind]bool{}
+		for _, k := range cfg.AutoClear {
+			p.autoClear[ControllerErrorKind(k)] = true
+		}
+	}
+	if p.afterRecovery == "" {
+		p.afterRecovery = afterRecoveryContinue
+	}
+	return p
+}
+
+func (p *recoveryPolicy) allows(ce *ControllerError) bool {
+	if p.autoClear == nil {
+		return ce.Recoverable
+	}
+	return p.autoClear[ce.Kind]
+}
+
+// recoveryEvent is one automatic recovery, or one the policy refused.
+type recoveryEvent struct {
+	time   time.Time
+	err    *ControllerError
+	action string
+	reason string
+}
+
+func (e recoveryEvent) toMap() map[string]any {
+	m := map[string]any{
+		"time":             e.time.Format(time.RFC3339Nano),
+		"action":           e.action,
+		controllerErrorKey: e.err.toMap(),
+	}
+	if e.reason != "" {
+		m["reason"] = e.reason
+	}
+	return m
+}
+
+// recoveryState applies the recovery policy and remembers what it has done. A nil policy clears
+// everything and carries on.
+type recoveryState struct {
+	mu          sync.Mutex
+	policy      *recoveryPolicy
+	clears      []time.Time // automatic clears within the policy window, oldest first
+	awaitingAck bool
+	servosHeld  bool
+	homePending bool
+	events      []recoveryEvent
+}
+
+func (r *recoveryState) recordLocked(e recoveryEvent) {
+	r.events = append(r.events, e)
+	if len(r.events) > maxRecoveryEvents {
+		r.events = r.events[len(r.events)-maxRecoveryEvents:]
+	}
+}
+
+// beginAutoClear returns an error if the policy does not allow ce to be cleared automatically now.
+func (r *recoveryState) beginAutoClear(ce *ControllerError, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.policy == nil {
+		return nil
+	}
+	var reason string
+	if !r.policy.allows(ce) {
+		reason = fmt.Sprintf("error_recovery does not auto-clear %s errors", ce.Kind)
+	} else if r.policy.maxAutoClears > 0 {
+		for len(r.clears) > 0 && now.Sub(r.clears[0]) > r.policy.window {
+			r.clears = r.clears[1:]
+		}
+		if len(r.clears) >= r.policy.maxAutoClears {
+			reason = fmt.Sprintf("error_recovery already cleared %d errors in the last %s", len(r.clears), r.policy.window)
+		}
+	}
+	if reason == "" {
+		return nil
+	}
+	r.recordLocked(recoveryEvent{time: now, err: ce, action: recoveryActionRefused, reason: reason})
+	return fmt.Errorf("%s; clear it with the clear_error DoCommand once it is safe", reason)
+}
+
+// cleared records that ce was cleared automatically and applies the policy's after_recovery action.
+func (r *recoveryState) cleared(ce *ControllerError, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recordLocked(recoveryEvent{time: now, err: ce, action: recoveryActionCleared})
+	if r.policy == nil {
+		return
+	}
+	r.clears = append(r.clears, now)
+	if r.policy.holdServos {
+		r.servosHeld = true
+		r.awaitingAck = true
+	}
+	switch r.policy.afterRecovery {
+	case afterRecoveryHome:
+		r.homePending = true
+	case afterRecoveryAcknowledge:
+		r.awaitingAck = true
+	default:
+	}
+}
+
+// acknowledge is an operator clearing the errors, which resets the policy's window and any pending
+// after_recovery action.
+func (r *recoveryState) acknowledge() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clears = nil
+	r.awaitingAck = false
+	r.servosHeld = false
+	r.homePending = false
+}
+
+func (r *recoveryState) motionBlocked() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.awaitingAck {
+		return errors.New("the arm recovered from an error automatically and error_recovery requires an operator to " +
+			"acknowledge it with the clear_error DoCommand before it moves again")
+	}
+	return nil
+}
+
+// servosBlocked returns an error while reenable_servos keeps the servos off after an automatic
+// recovery.
+func (r *recoveryState) servosBlocked() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.servosHeld {
+		return errors.New("the arm recovered from an error automatically and error_recovery keeps its servos off " +
+			"until an operator runs the clear_error DoCommand")
+	}
+	return nil
+}
+
+func (r *recoveryState) setHomePending(pending bool) (was bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	was, r.homePending = r.homePending, pending
+	return was
+}
+
+func (r *recoveryState) toMap() map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := make([]any, len(r.events))
+	for i, e := range r.events {
+		events[i] = e.toMap()
+	}
+	return map[string]any{
+		"policy_configured":        r.policy != nil,
+		"auto_clears_in_window":    float64(len(r.clears)),
+		"awaiting_acknowledgement": r.awaitingAck,
+		"servos_held":              r.servosHeld,
+		"home_pending":             r.homePending,
+		"recoveries":               events,
+	}
+}
+
+// autoClearAllowed asks the recovery policy whether a controller error may be cleared without an
+// operator. Operator clears always are.
+func (x *xArm) autoClearAllowed(ce *ControllerError, operator bool) error {
+	if operator {
+		return nil
+	}
+	return x.recovery.beginAutoClear(ce, time.Now())
+}
+
+func (x *xArm) recordCleared(ctx context.Context, ce *ControllerError, operator bool) {
+	e := armEvent{
+		Type:     eventTypeClear,
+		Message:  "cleared by an operator: " + ce.Error(),
+		Kind:     string(ce.Kind),
+		Code:     int(ce.Code),
+		WarnCode: int(ce.WarnCode),
+		Joint:    ce.Joint,
+	}
+	if !operator {
+		e.Message = "cleared automatically: " + ce.Error()
+		x.recovery.cleared(ce, time.Now())
+		x.logger.Warnf("automatically cleared arm error: %v", ce)
+	}
+	x.recordEvent(ctx, e)
+}
+
+// clearErrors is an operator clearing the arm's errors and warnings, whatever the recovery policy.
+func (x *xArm) clearErrors(ctx context.Context) error {
+	if err := x.readyState(ctx, false, true); err != nil {
+		return err
+	}
+	x.recovery.acknowledge()
+	return nil
+}
+
+// prepareMotionAfterRecovery applies the policy's after_recovery action before a move.
+func (x *xArm) prepareMotionAfterRecovery(ctx context.Context) error {
+	if err := x.recovery.motionBlocked(); err != nil {
+		return err
+	}
+	if !x.recovery.setHomePending(false) {
+		return nil
+	}
+	x.logger.Info("homing the arm after an automatic error recovery")
+	if err := x.home(ctx); err != nil {
+		x.recovery.setHomePending(true)
+		return fmt.Errorf("homing after an automatic error recovery: %w", err)
+	}
+	return nil
+}
+
+// home moves every joint to zero with a single controller-planned move. It refuses when zero is
+// outside a joint's limits, which take in bad-joints, joint_limits and cable_ranges.
+func (x *xArm) home(ctx context.Context) error {
+	target := make([]referenceframe.Input, x.dof)
+	if x.model != nil {
+		if err := checkJointLimits(x.model.DoF(), nil, target); err != nil {
+			return fmt.Errorf("cannot home with every joint at 0: %w", err)
+		}
+	}
+	if err := x.start(ctx, true); err != nil {
+		return err
+	}
+	mo := x.moveOptions(nil, nil)
+	mo.direct = true
+	if err := x.sendJointStep(ctx, target, mo); err != nil {
+		return err
+	}
+	return x.waitForMotionStop(ctx)
+}
@@ -0,0 +1,131 @@
+package arm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/test"
+)
+
+func TestErrorRecoveryConfigValidate(t *testing.T) {
+	no := false
+	for _, tc := range []struct {
+		name string
+		cfg  ErrorRecoveryConfig
+		ok   bool
+	}{
+		{"empty", ErrorRecoveryConfig{}, true},
+		{"full", ErrorRecoveryConfig{
+			AutoClear: []string{"warning", "communication"}, MaxAutoClears: 3, WindowSec: 60,
+			ReenableServos: &no, AfterRecovery: afterRecoveryHome,
+		}, true},
+		{"unknown kind", ErrorRecoveryConfig{AutoClear: []string{"gremlins"}}, false},
+		{"limit without window", ErrorRecoveryConfig{MaxAutoClears: 3}, false},
+		{"negative window", ErrorRecoveryConfig{WindowSec: -1}, false},
+		{"bad after recovery", ErrorRecoveryConfig{AfterRecovery: "reboot"}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.validate()
+			if tc.ok {
+				test.That(t, err, test.ShouldBeNil)
+			} else {
+				test.That(t, err, test.ShouldNotBeNil)
+			}
+		})
+	}
+}
+
+func TestRecoveryStateWithoutPolicy(t *testing.T) {
+	var r recoveryState
+	collision := newControllerError(0x40, errCodeCollision, 0)
+
+	// Without a policy everything is cleared and the arm carries on, as before policies existed.
+	test.That(t, r.beginAutoClear(collision, time.Now()), test.ShouldBeNil)
+	r.cleared(collision, time.Now())
+	test.That(t, r.motionBlocked(), test.ShouldBeNil)
+	test.That(t, r.setHomePending(false), test.ShouldBeFalse)
+	test.That(t, len(r.toMap()["recoveries"].([]any)), test.ShouldEqual, 1)
+}
+
+func TestRecoveryStatePolicy(t *testing.T) {
+	r := recoveryState{policy: newRecoveryPolicy(&ErrorRecoveryConfig{MaxAutoClears: 2, WindowSec: 60})}
+	now := time.Now()
+	jointLimit := newControllerError(0x40, 0x17, 0)
+	collision := newControllerError(0x40, errCodeCollision, 0)
+
+	// By default only recoverable errors are cleared.
+	test.That(t, r.beginAutoClear(collision, now), test.ShouldNotBeNil)
+	for range 2 {
+		test.That(t, r.beginAutoClear(jointLimit, now), test.ShouldBeNil)
+		r.cleared(jointLimit, now)
+	}
+	// The window is full until it slides past the earlier clears, or an operator acknowledges.
+	test.That(t, r.beginAutoClear(jointLimit, now.Add(time.Second)), test.ShouldNotBeNil)
+	test.That(t, r.beginAutoClear(jointLimit, now.Add(61*time.Second)), test.ShouldBeNil)
+	r.cleared(jointLimit, now.Add(61*time.Second))
+	r.acknowledge()
+	test.That(t, r.beginAutoClear(jointLimit, now.Add(62*time.Second)), test.ShouldBeNil)
+
+	status := r.toMap()
+	test.That(t, status["auto_clears_in_window"], test.ShouldEqual, 0.)
+	recoveries := status["recoveries"].([]any)
+	test.That(t, len(recoveries), test.ShouldEqual, 5)
+	test.That(t, recoveries[0].(map[string]any)["action"], test.ShouldEqual, recoveryActionRefused)
+	test.That(t, recoveries[1].(map[string]any)["action"], test.ShouldEqual, recoveryActionCleared)
+}
+
+func TestRecoveryStateAfterRecovery(t *testing.T) {
+	warning := newControllerError(0x20, 0, 0x0C)
+
+	r := recoveryState{policy: newRecoveryPolicy(&ErrorRecoveryConfig{
+		AutoClear: []string{string(ErrorKindWarning)}, AfterRecovery: afterRecoveryAcknowledge,
+	})}
+	test.That(t, r.beginAutoClear(newControllerError(0x40, 0x17, 0), time.Now()), test.ShouldNotBeNil)
+	r.cleared(warning, time.Now())
+	test.That(t, r.motionBlocked(), test.ShouldNotBeNil)
+	r.acknowledge()
+	test.That(t, r.motionBlocked(), test.ShouldBeNil)
+
+	r = recoveryState{policy: newRecoveryPolicy(&ErrorRecoveryConfig{AfterRecovery: afterRecoveryHome})}
+	r.cleared(warning, time.Now())
+	test.That(t, r.motionBlocked(), test.ShouldBeNil)
+	test.That(t, r.setHomePending(false), test.ShouldBeTrue)
+	test.That(t, r.setHomePending(false), test.ShouldBeFalse)
+}
+
+func TestHomeOutsideJointLimits(t *testing.T) {
+	m, err := MakeModelFrame("", ModelName6DOF, nil, nil, false, nil, logging.NewTestLogger(t), 0)
+	test.That(t, err, test.ShouldBeNil)
+	m, err = applyJointLimits(m, []JointLimitConfig{{Joint: 1, MinDegs: 10, MaxDegs: 90}})
+	test.That(t, err, test.ShouldBeNil)
+
+	// Refused before anything is sent to the controller.
+	x := &xArm{dof: 6, model: m}
+	err = x.home(context.Background())
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "joint 1")
+}
+
+func TestRecoveryKeepsServosOff(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	c := &fakeArmController{warnCode: 0x0C}
+	x := &xArm{logger: logger, cmdConn: newModbusConn(startFakeArmController(t, c), logger, nil)}
+	no := false
+	x.recovery.policy = newRecoveryPolicy(&ErrorRecoveryConfig{ReenableServos: &no})
+	x.started.Store(-1)
+
+	// The warning is cleared automatically, but the servos stay off until an operator clears.
+	err := x.start(context.Background(), true)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "clear_error")
+	test.That(t, c.sent(regMap["ClearWarn"]), test.ShouldBeTrue)
+	test.That(t, c.sent(regMap["ToggleServo"]), test.ShouldBeFalse)
+	test.That(t, x.recovery.toMap()["servos_held"], test.ShouldBeTrue)
+	test.That(t, x.recovery.motionBlocked(), test.ShouldNotBeNil)
+
+	test.That(t, x.clearErrors(context.Background()), test.ShouldBeNil)
+	test.That(t, x.start(context.Background(), true), test.ShouldBeNil)
+	test.That(t, c.sent(regMap["ToggleServo"]), test.ShouldBeTrue)
+}
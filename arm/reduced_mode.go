@@ -0,0 +1,327 @@
+package arm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.viam.com/rdk/utils"
+	goutils "go.viam.com/utils"
+)
+
+// Reduced mode is the controller's collaborative mode: while it is on, the controller holds the arm to
+// lower TCP and joint speeds and, if joint limits were pushed to it, to its reduced joint range. The
+// `reduced_mode` config sets those speeds and can name a trigger, a controller digital input or a
+// reading from a Viam sensor such as an area scanner, that turns reduced mode on and off as it changes.
+// It can also be switched by hand with the `set_reduced_mode` DoCommand. While it is on, moves planned
+// by the module are slowed to the reduced joint speed too.
+
+const (
+	setReducedModeKey = "set_reduced_mode"
+	getReducedModeKey = "get_reduced_mode"
+	reducedModeKey    = "reduced_mode"
+
+	reducedModeSourceManual       = "manual"
+	reducedModeSourceStartup      = "startup"
+	reducedModeSourceDigitalInput = "digital_input"
+	reducedModeSourceSensor       = "sensor"
+
+	// maxControllerDigitalInput is the last controller input: CI0-CI7 are 0-7 and DI0-DI7 are 8-15.
+	maxControllerDigitalInput = 15
+
+	defaultReducedModePollInterval = 100 * time.Millisecond
+)
+
+// ReducedModeConfig sets the controller's reduced mode limits and what turns reduced mode on.
+type ReducedModeConfig struct {
+	TCPSpeedMMPerSec     float64 `json:"tcp_speed_mm_per_sec,omitempty"`
+	JointSpeedDegsPerSec float64 `json:"joint_speed_degs_per_sec,omitempty"`
+
+	// DigitalInput is the controller input that turns reduced mode on while it is high, or low with
+	// ActiveLow: 0-7 for CI0-CI7 and 8-15 for DI0-DI7.
+	DigitalInput *int `json:"digital_input,omitempty"`
+	ActiveLow    bool `json:"active_low,omitempty"`
+	// Sensor names a sensor whose SensorReading turns reduced mode on while it is true or non-zero.
+	Sensor          string  `json:"sensor,omitempty"`
+	SensorReading   string  `json:"sensor_reading,omitempty"`
+	PollIntervalSec float64 `json:"poll_interval_sec,omitempty"`
+}
+
+func (cfg *ReducedModeConfig) validate() error {
+	if cfg.TCPSpeedMMPerSec < 0 {
+		return fmt.Errorf("reduced_mode tcp_speed_mm_per_sec cannot be negative, got %f", cfg.TCPSpeedMMPerSec)
+	}
+	if cfg.JointSpeedDegsPerSec != 0 && (cfg.JointSpeedDegsPerSec < minSpeed || cfg.JointSpeedDegsPerSec > maxSpeed) {
+		return fmt.Errorf("reduced_mode joint_speed_degs_per_sec must be between %f and %f, got %f",
+			minSpeed, maxSpeed, cfg.JointSpeedDegsPerSec)
+	}
+	if cfg.DigitalInput != nil && (*cfg.DigitalInput < 0 || *cfg.DigitalInput > maxControllerDigitalInput) {
+		return fmt.Errorf("reduced_mode digital_input must be between 0 and %d, got %d", maxControllerDigitalInput, *cfg.DigitalInput)
+	}
+	if cfg.DigitalInput != nil && cfg.Sensor != "" {
+		return errors.New("reduced_mode can be triggered by a digital_input or a sensor, not both")
+	}
+	if cfg.Sensor != "" && cfg.SensorReading == "" {
+		return errors.New("reduced_mode sensor needs a sensor_reading")
+	}
+	if cfg.PollIntervalSec < 0 {
+		return fmt.Errorf("reduced_mode poll_interval_sec cannot be negative, got %f", cfg.PollIntervalSec)
+	}
+	return nil
+}
+
+func (cfg *ReducedModeConfig) triggerSource() string {
+	switch {
+	case cfg.DigitalInput != nil:
+		return reducedModeSourceDigitalInput
+	case cfg.Sensor != "":
+		return reducedModeSourceSensor
+	default:
+		return ""
+	}
+}
+
+func (cfg *ReducedModeConfig) pollInterval() time.Duration {
+	if cfg.PollIntervalSec == 0 {
+		return defaultReducedModePollInterval
+	}
+	return time.Duration(cfg.PollIntervalSec * float64(time.Second))
+}
+
+// reducedModeState is whether reduced mode is on, as last set or read by the module.
+type reducedModeState struct {
+	mu         sync.Mutex
+	active     bool
+	source     string
+	changed    time.Time
+	jointSpeed float64 // radians per second, or 0 for no limit of the module's own
+}
+
+func (r *reducedModeState) set(active bool, source string) (changed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changed = r.active != active
+	if changed || r.source == "" {
+		r.changed = time.Now()
+	}
+	r.active, r.source = active, source
+	return changed
+}
+
+// speedLimit returns the joint speed moves are held to, or 0 if there is none.
+func (r *reducedModeState) speedLimit() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.active {
+		return 0
+	}
+	return r.jointSpeed
+}
+
+func (r *reducedModeState) toMap() map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := map[string]any{reducedModeKey: r.active, "source": r.source}
+	if !r.changed.IsZero() {
+		m["changed"] = r.changed.Format(time.RFC3339Nano)
+	}
+	return m
+}
+
+// controllerDigitalInputs decodes the controller's digital inputs, CI0-CI7 in the low byte and DI0-DI7
+// in the high byte.
+func controllerDigitalInputs(params []byte) (uint16, error) {
+	if len(params) < 3 {
+		return 0, fmt.Errorf("controller digital input response too short: %d bytes", len(params))
+	}
+	return binary.BigEndian.Uint16(params[1:3]), nil
+}
+
+func digitalInputActive(inputs uint16, input int, activeLow bool) bool {
+	high := inputs&(1<<input) != 0
+	return high != activeLow
+}
+
+// sensorReadingActive reports whether a sensor reading asks for reduced mode: a bool that is true or a
+// number that is not zero.
+func sensorReadingActive(readings map[string]any, key string) (bool, error) {
+	v, ok := readings[key]
+	if !ok {
+		return false, fmt.Errorf("sensor has no reading %q", key)
+	}
+	switch r := v.(type) {
+	case bool:
+		return r, nil
+	case float64:
+		return r != 0, nil
+	case float32:
+		return r != 0, nil
+	case int:
+		return r != 0, nil
+	case int32:
+		return r != 0, nil
+	case int64:
+		return r != 0, nil
+	default:
+		return false, fmt.Errorf("sensor reading %q is a %T, not a bool or number", key, v)
+	}
+}
+
+func (x *xArm) readControllerDigitalInputs(ctx context.Context) (uint16, error) {
+	res, err := x.send(ctx, x.newCmd(regMap["CGPIODigital"]), true)
+	if err != nil {
+		return 0, err
+	}
+	return controllerDigitalInputs(res.params)
+}
+
+func (x *xArm) getControllerReducedMode(ctx context.Context) (bool, error) {
+	res, err := x.send(ctx, x.getReducedModeCmd(), true)
+	if err != nil {
+		return false, err
+	}
+	if len(res.params) < 2 {
+		return false, fmt.Errorf("reduced mode response too short: %d bytes", len(res.params))
+	}
+	return res.params[1] != 0, nil
+}
+
+func (x *xArm) getReducedModeCmd() cmd {
+	return x.newCmd(regMap["GetReduced"])
+}
+
+func (x *xArm) setReducedModeCmd(active bool) cmd {
+	c := x.newCmd(regMap["SetReduced"])
+	var on byte
+	if active {
+		on = 1
+	}
+	c.params = append(c.params, on)
+	return c
+}
+
+// reducedSpeedCmd sets one of the reduced mode speed limits: the TCP speed in mm/s, or the joint
+// speed in rad/s.
+func (x *xArm) reducedSpeedCmd(reg byte, speed float64) cmd {
+	c := x.newCmd(reg)
+	c.params = binary.LittleEndian.AppendUint32(c.params, math.Float32bits(float32(speed)))
+	return c
+}
+
+// setReducedMode turns the controller's reduced mode on or off and records why.
+func (x *xArm) setReducedMode(ctx context.Context, active bool, source string) error {
+	if _, err := x.send(ctx, x.setReducedModeCmd(active), true); err != nil {
+		return err
+	}
+	if x.reduced.set(active, source) {
+		state := "off"
+		if active {
+			state = "on"
+		}
+		msg := fmt.Sprintf("reduced mode %s (%s)", state, source)
+		x.logger.Info(msg)
+		x.recordEvent(ctx, armEvent{Type: eventTypeModeChange, Message: msg})
+	}
+	return nil
+}
+
+// setupReducedMode sends the configured reduced mode limits to the controller, reads whether reduced
+// mode is already on, and starts watching the trigger if there is one.
+func (x *xArm) setupReducedMode(ctx context.Context, cfg *ReducedModeConfig) error {
+	if cfg.TCPSpeedMMPerSec > 0 {
+		if _, err := x.send(ctx, x.reducedSpeedCmd(regMap["ReducedTSpeed"], cfg.TCPSpeedMMPerSec), true); err != nil {
+			return fmt.Errorf("setting the reduced mode TCP speed: %w", err)
+		}
+	}
+	if cfg.JointSpeedDegsPerSec > 0 {
+		speed := utils.DegToRad(cfg.JointSpeedDegsPerSec)
+		if _, err := x.send(ctx, x.reducedSpeedCmd(regMap["ReducedJSpeed"], speed), true); err != nil {
+			return fmt.Errorf("setting the reduced mode joint speed: %w", err)
+		}
+		x.reduced.mu.Lock()
+		x.reduced.jointSpeed = speed
+		x.reduced.mu.Unlock()
+	}
+	active, err := x.getControllerReducedMode(ctx)
+	if err != nil {
+		return fmt.Errorf("reading reduced mode: %w", err)
+	}
+	x.reduced.set(active, reducedModeSourceStartup)
+
+	if source := cfg.triggerSource(); source != "" {
+		x.workers.Add(func(ctx context.Context) { x.watchReducedModeTrigger(ctx, cfg, source) })
+	}
+	return nil
+}
+
+// watchReducedModeTrigger polls the reduced mode trigger and follows it. Only changes of the trigger
+// switch reduced mode, so a manual switch holds until the trigger next changes. A trigger that can't
+// be read fails safe: reduced mode is held on until a read says the trigger is off.
+func (x *xArm) watchReducedModeTrigger(ctx context.Context, cfg *ReducedModeConfig, source string) {
+	var last *bool
+	var lastErr string
+	for goutils.SelectContextOrWait(ctx, cfg.pollInterval()) {
+		active, err := x.reducedModeTriggered(ctx, cfg)
+		if err != nil {
+			err = fmt.Errorf("%w; holding reduced mode on until it can be read", err)
+			active = true
+			// Switched on at every failed poll, so a manual switch can't take it off meanwhile.
+			last = nil
+		}
+		if last == nil || *last != active {
+			if setErr := x.setReducedMode(ctx, active, source); setErr != nil {
+				err = multierr.Combine(err, setErr)
+			} else {
+				last = &active
+			}
+		}
+		if err != nil && ctx.Err() == nil {
+			// Log each distinct failure once rather than at every poll.
+			if err.Error() != lastErr {
+				x.logger.Warnf("reduced mode %s trigger: %v", source, err)
+			}
+			lastErr = err.Error()
+			continue
+		}
+		lastErr = ""
+	}
+}
+
+func (x *xArm) reducedModeTriggered(ctx context.Context, cfg *ReducedModeConfig) (bool, error) {
+	if cfg.DigitalInput != nil {
+		inputs, err := x.readControllerDigitalInputs(ctx)
+		if err != nil {
+			return false, err
+		}
+		return digitalInputActive(inputs, *cfg.DigitalInput, cfg.ActiveLow), nil
+	}
+	if x.reducedModeSensor == nil {
+		return false, fmt.Errorf("sensor %q is not available", cfg.Sensor)
+	}
+	readings, err := x.reducedModeSensor.Readings(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	return sensorReadingActive(readings, cfg.SensorReading)
+}
+
+// getReducedModeCommand handles the `get_reduced_mode` DoCommand, reading the mode from the controller.
+func (x *xArm) getReducedModeCommand(ctx context.Context) (map[string]any, error) {
+	active, err := x.getControllerReducedMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := x.reduced.toMap()
+	m[reducedModeKey] = active
+	if cfg := x.conf.ReducedMode; cfg != nil {
+		m["tcp_speed_mm_per_sec"] = cfg.TCPSpeedMMPerSec
+		m["joint_speed_degs_per_sec"] = cfg.JointSpeedDegsPerSec
+		m["trigger"] = cfg.triggerSource()
+	}
+	return m, nil
+}
@@ -0,0 +1,168 @@
+package arm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+)
+
+func TestReducedModeConfigValidate(t *testing.T) {
+	input := func(i int) *int { return &i }
+	test.That(t, (&ReducedModeConfig{TCPSpeedMMPerSec: 250, JointSpeedDegsPerSec: 30}).validate(), test.ShouldBeNil)
+	test.That(t, (&ReducedModeConfig{DigitalInput: input(9)}).validate(), test.ShouldBeNil)
+	test.That(t, (&ReducedModeConfig{Sensor: "scanner", SensorReading: "person_present"}).validate(), test.ShouldBeNil)
+
+	test.That(t, (&ReducedModeConfig{TCPSpeedMMPerSec: -1}).validate(), test.ShouldNotBeNil)
+	test.That(t, (&ReducedModeConfig{JointSpeedDegsPerSec: 500}).validate(), test.ShouldNotBeNil)
+	test.That(t, (&ReducedModeConfig{DigitalInput: input(16)}).validate(), test.ShouldNotBeNil)
+	test.That(t, (&ReducedModeConfig{Sensor: "scanner"}).validate(), test.ShouldNotBeNil)
+	test.That(t, (&ReducedModeConfig{DigitalInput: input(1), Sensor: "scanner", SensorReading: "x"}).validate(), test.ShouldNotBeNil)
+	test.That(t, (&ReducedModeConfig{PollIntervalSec: -1}).validate(), test.ShouldNotBeNil)
+
+	test.That(t, (&ReducedModeConfig{}).triggerSource(), test.ShouldEqual, "")
+	test.That(t, (&ReducedModeConfig{DigitalInput: input(0)}).triggerSource(), test.ShouldEqual, reducedModeSourceDigitalInput)
+	test.That(t, (&ReducedModeConfig{}).pollInterval(), test.ShouldEqual, defaultReducedModePollInterval)
+}
+
+func TestControllerDigitalInputs(t *testing.T) {
+	inputs, err := controllerDigitalInputs([]byte{0x00, 0x02, 0x01})
+	test.That(t, err, test.ShouldBeNil)
+	// DI1 is bit 9 and CI0 is bit 0.
+	test.That(t, digitalInputActive(inputs, 9, false), test.ShouldBeTrue)
+	test.That(t, digitalInputActive(inputs, 0, false), test.ShouldBeTrue)
+	test.That(t, digitalInputActive(inputs, 8, false), test.ShouldBeFalse)
+	test.That(t, digitalInputActive(inputs, 8, true), test.ShouldBeTrue)
+	test.That(t, digitalInputActive(inputs, 9, true), test.ShouldBeFalse)
+
+	_, err = controllerDigitalInputs([]byte{0x00, 0x02})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestSensorReadingActive(t *testing.T) {
+	readings := map[string]any{"present": true, "count": 0.0, "distance": 1.5, "name": "zone"}
+	active, err := sensorReadingActive(readings, "present")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, active, test.ShouldBeTrue)
+	active, err = sensorReadingActive(readings, "count")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, active, test.ShouldBeFalse)
+	active, err = sensorReadingActive(readings, "distance")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, active, test.ShouldBeTrue)
+
+	_, err = sensorReadingActive(readings, "name")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = sensorReadingActive(readings, "missing")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestReducedModeSpeedLimit(t *testing.T) {
+	var r reducedModeState
+	r.jointSpeed = utils.DegToRad(30)
+	test.That(t, r.speedLimit(), test.ShouldEqual, 0)
+
+	test.That(t, r.set(true, reducedModeSourceManual), test.ShouldBeTrue)
+	test.That(t, r.speedLimit(), test.ShouldAlmostEqual, utils.DegToRad(30))
+	test.That(t, r.set(true, reducedModeSourceSensor), test.ShouldBeFalse)
+	test.That(t, r.toMap()["source"], test.ShouldEqual, reducedModeSourceSensor)
+
+	test.That(t, r.set(false, reducedModeSourceSensor), test.ShouldBeTrue)
+	test.That(t, r.speedLimit(), test.ShouldEqual, 0)
+	test.That(t, r.toMap()[reducedModeKey], test.ShouldBeFalse)
+}
+
+func TestReducedModeCmds(t *testing.T) {
+	x := &xArm{cmdConn: &modbusConn{}}
+	// Frames are tid, protocol, length, then the register byte.
+	const regByte = 6
+
+	get := x.getReducedModeCmd()
+	test.That(t, get.bytes()[regByte], test.ShouldEqual, byte(0x31))
+
+	set := x.setReducedModeCmd(true)
+	b := set.bytes()
+	test.That(t, b[regByte], test.ShouldEqual, byte(0x32))
+	test.That(t, b[regByte+1:], test.ShouldResemble, []byte{1})
+	set = x.setReducedModeCmd(false)
+	test.That(t, set.bytes()[regByte+1:], test.ShouldResemble, []byte{0})
+
+	tcp := x.reducedSpeedCmd(regMap["ReducedTSpeed"], 250)
+	b = tcp.bytes()
+	test.That(t, b[regByte], test.ShouldEqual, byte(0x2F))
+	test.That(t, math.Float32frombits(binary.LittleEndian.Uint32(b[regByte+1:])), test.ShouldEqual, float32(250))
+
+	joint := x.reducedSpeedCmd(regMap["ReducedJSpeed"], 0.5)
+	b = joint.bytes()
+	test.That(t, b[regByte], test.ShouldEqual, byte(0x30))
+	test.That(t, math.Float32frombits(binary.LittleEndian.Uint32(b[regByte+1:])), test.ShouldEqual, float32(0.5))
+}
+
+// fakeSensor is a sensor whose readings come from readings.
+type fakeSensor struct {
+	sensor.Sensor
+
+	readings func() (map[string]any, error)
+}
+
+func (s *fakeSensor) Readings(context.Context, map[string]any) (map[string]any, error) {
+	return s.readings()
+}
+
+func TestReducedModeTriggerFailsSafe(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	c := &fakeArmController{}
+	var mu sync.Mutex
+	readErr := errors.New("scanner offline")
+	s := &fakeSensor{}
+	s.readings = func() (map[string]any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if readErr != nil {
+			return nil, readErr
+		}
+		return map[string]any{"present": false}, nil
+	}
+	x := &xArm{
+		logger:            logger,
+		cmdConn:           newModbusConn(startFakeArmController(t, c), logger, nil),
+		reducedModeSensor: s,
+	}
+	cfg := &ReducedModeConfig{Sensor: "scanner", SensorReading: "present", PollIntervalSec: 0.001}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		x.watchReducedModeTrigger(ctx, cfg, reducedModeSourceSensor)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Reduced mode goes on while the trigger can't be read, and only a read turns it off again.
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, c.reducedSwitches(), test.ShouldNotBeEmpty)
+	})
+	test.That(t, c.reducedSwitches()[0], test.ShouldBeTrue)
+	test.That(t, x.reduced.toMap()[reducedModeKey], test.ShouldBeTrue)
+
+	mu.Lock()
+	readErr = nil
+	mu.Unlock()
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, x.reduced.toMap()[reducedModeKey], test.ShouldBeFalse)
+	})
+	switches := c.reducedSwitches()
+	test.That(t, switches[len(switches)-1], test.ShouldBeFalse)
+}
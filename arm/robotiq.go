@@ -0,0 +1,473 @@
+package arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/gripper"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/spatialmath"
+	rutils "go.viam.com/rdk/utils"
+	"go.viam.com/utils"
+)
+
+// ModelNameRobotiq2F is a Robotiq 2F-85 or 2F-140 on the arm's tool RS-485 port.
+const ModelNameRobotiq2F = "robotiq_2f"
+
+// Robotiq2FModel model for a Robotiq 2F gripper on the tool RS-485 port.
+var Robotiq2FModel = family.WithModel(ModelNameRobotiq2F)
+
+// Robotiq 2F variants.
+const (
+	robotiq2F85  = "2f_85"
+	robotiq2F140 = "2f_140"
+)
+
+// Robotiq 2F Modbus map, from the 2F-85/2F-140 instruction manual. Requests are written from
+// robotiqRequestReg and status is read from robotiqStatusReg, three registers each.
+const (
+	robotiqRequestReg uint16 = 0x03E8
+	robotiqStatusReg  uint16 = 0x07D0
+	robotiqRegs              = 3
+
+	defaultRobotiqSlaveID = 9
+	defaultRobotiqBaud    = 115200
+	defaultRobotiqSpeed   = 255
+	defaultRobotiqForce   = 128
+
+	// Action request bits, in the high byte of the first request register.
+	robotiqActionActivate = 0x01 // rACT
+	robotiqActionGoTo     = 0x08 // rGTO
+
+	// Positions run from fully open to fully closed.
+	robotiqOpenPosition   = 0
+	robotiqClosedPosition = 255
+
+	// gSTA: the gripper is activated once it reads robotiqActivationComplete.
+	robotiqActivationComplete = 3
+	// gOBJ values.
+	robotiqObjectMoving         = 0
+	robotiqObjectWhileOpening   = 1
+	robotiqObjectWhileClosing   = 2
+	robotiqObjectAtRequestedPos = 3
+	// Faults at or above robotiqMajorFault stop the gripper until it is reactivated; those below are
+	// warnings the gripper recovers from.
+	robotiqMajorFault = 0x0A
+
+	robotiqPollInterval      = 50 * time.Millisecond
+	robotiqActivationTimeout = 10 * time.Second
+
+	robotiqActivateKey = "activate"
+)
+
+// Robotiq2FConfig config for a Robotiq 2F gripper.
+type Robotiq2FConfig struct {
+	Arm string `json:"arm"`
+	// Variant is "2f_85" (the default) or "2f_140" and only selects the collision geometry.
+	Variant  string `json:"variant,omitempty"`
+	SlaveID  int    `json:"slave_id,omitempty"`
+	BaudRate int    `json:"baud_rate,omitempty"`
+	// Speed and Force are the gripper's 0-255 scales. Pointers so 0, the slowest and gentlest, can
+	// be set.
+	Speed *int `json:"speed,omitempty"`
+	Force *int `json:"force,omitempty"`
+}
+
+// Validate validates the config.
+func (cfg *Robotiq2FConfig) Validate(path string) ([]string, []string, error) {
+	if cfg.Arm == "" {
+		return nil, nil, utils.NewConfigValidationFieldRequiredError(path, "arm")
+	}
+	switch cfg.Variant {
+	case "", robotiq2F85, robotiq2F140:
+	default:
+		return nil, nil, fmt.Errorf(`variant must be %q or %q, got %q`, robotiq2F85, robotiq2F140, cfg.Variant)
+	}
+	if err := validateRS485(cfg.SlaveID, cfg.BaudRate); err != nil {
+		return nil, nil, err
+	}
+	for name, v := range map[string]*int{"speed": cfg.Speed, "force": cfg.Force} {
+		if v != nil && (*v < 0 || *v > 255) {
+			return nil, nil, fmt.Errorf("%s must be between 0 and 255, got %d", name, *v)
+		}
+	}
+	return []string{cfg.Arm}, nil, nil
+}
+
+func init() {
+	resource.RegisterComponent(
+		gripper.API,
+		Robotiq2FModel,
+		resource.Registration[gripper.Gripper, *Robotiq2FConfig]{
+			Constructor: newRobotiq2F,
+		})
+}
+
+// robotiqStatus is the decoded status block.
+type robotiqStatus struct {
+	activated  bool // gACT
+	goTo       bool // gGTO
+	activation byte // gSTA
+	object     byte // gOBJ
+	fault      byte // gFLT, with kFLT in the high nibble
+	requested  byte // echo of the requested position
+	position   byte
+	current    byte // tens of milliamps
+}
+
+func decodeRobotiqStatus(words []uint16) (robotiqStatus, error) {
+	if len(words) != robotiqRegs {
+		return robotiqStatus{}, fmt.Errorf("robotiq status has %d registers, want %d", len(words), robotiqRegs)
+	}
+	status := byte(words[0] >> 8)
+	return robotiqStatus{
+		activated:  status&0x01 != 0,
+		goTo:       status&0x08 != 0,
+		activation: (status >> 4) & 0x03,
+		object:     (status >> 6) & 0x03,
+		fault:      byte(words[1] >> 8),
+		requested:  byte(words[1]),
+		position:   byte(words[2] >> 8),
+		current:    byte(words[2]),
+	}, nil
+}
+
+func (s robotiqStatus) holding() bool {
+	return s.object == robotiqObjectWhileOpening || s.object == robotiqObjectWhileClosing
+}
+
+// moveDone reports whether the gripper has taken the move to request and finished it.
+func (s robotiqStatus) moveDone(request byte) bool {
+	return s.goTo && s.requested == request && s.object != robotiqObjectMoving
+}
+
+func (s robotiqStatus) err() error {
+	if f := s.fault & 0x0F; f >= robotiqMajorFault {
+		return fmt.Errorf("robotiq gripper fault 0x%02X", f)
+	}
+	return nil
+}
+
+func (s robotiqStatus) toMap() map[string]any {
+	return map[string]any{
+		"activated":  s.activated && s.activation == robotiqActivationComplete,
+		"position":   int(s.position),
+		"requested":  int(s.requested),
+		"object":     int(s.object),
+		"holding":    s.holding(),
+		"fault":      int(s.fault),
+		"current_mA": int(s.current) * 10,
+	}
+}
+
+// robotiqMoveRegs is the request block for a move to pos.
+func robotiqMoveRegs(pos, speed, force byte) []uint16 {
+	return []uint16{
+		(robotiqActionActivate | robotiqActionGoTo) << 8,
+		uint16(pos),
+		uint16(speed)<<8 | uint16(force),
+	}
+}
+
+type myRobotiq2F struct {
+	resource.AlwaysRebuild
+
+	name resource.Name
+	mf   referenceframe.Model
+
+	x     *xArm
+	slave byte
+
+	moveLock sync.Mutex
+	isMoving atomic.Bool
+
+	variant      string
+	speed, force byte
+
+	logger logging.Logger
+}
+
+func newRobotiq2F(ctx context.Context, deps resource.Dependencies, config resource.Config, logger logging.Logger) (gripper.Gripper, error) {
+	newConf, err := resource.NativeConfig[*Robotiq2FConfig](config)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := arm.FromProvider(deps, newConf.Arm)
+	if err != nil {
+		return nil, err
+	}
+	x, err := rutils.AssertType[*xArm](a)
+	if err != nil {
+		return nil, fmt.Errorf("robotiq gripper: %w", err)
+	}
+
+	g := &myRobotiq2F{
+		name:    config.ResourceName(),
+		x:       x,
+		slave:   defaultRobotiqSlaveID,
+		variant: robotiq2F85,
+		speed:   defaultRobotiqSpeed,
+		force:   defaultRobotiqForce,
+		logger:  logger,
+	}
+	if newConf.SlaveID != 0 {
+		g.slave = byte(newConf.SlaveID)
+	}
+	if newConf.Variant != "" {
+		g.variant = newConf.Variant
+	}
+	if newConf.Speed != nil {
+		g.speed = byte(*newConf.Speed)
+	}
+	if newConf.Force != nil {
+		g.force = byte(*newConf.Force)
+	}
+
+	geoms, err := robotiq2FGeometries(g.variant)
+	if err != nil {
+		return nil, err
+	}
+	if g.mf, err = makeGeometryModel(ModelNameRobotiq2F, geoms); err != nil {
+		return nil, fmt.Errorf("robotiq gripper kinematics: %w", err)
+	}
+
+	baud := defaultRobotiqBaud
+	if newConf.BaudRate != 0 {
+		baud = newConf.BaudRate
+	}
+	if err := x.setRS485Baud(ctx, toolModbusHostID, baud); err != nil {
+		return nil, fmt.Errorf("robotiq gripper: %w", err)
+	}
+	if err := g.activate(ctx, false); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *myRobotiq2F) readStatus(ctx context.Context) (robotiqStatus, error) {
+	r, err := g.x.readRS485Registers(ctx, toolModbusHostID, g.slave, modbusReadInput, robotiqStatusReg, robotiqRegs)
+	if err != nil {
+		return robotiqStatus{}, err
+	}
+	if r.exception != 0 {
+		return robotiqStatus{}, fmt.Errorf("robotiq status read rejected with Modbus exception 0x%02X (%v)", r.exception, r.params)
+	}
+	return decodeRobotiqStatus(r.words())
+}
+
+func (g *myRobotiq2F) writeRequest(ctx context.Context, regs []uint16) error {
+	exc, err := g.x.writeRS485Registers(ctx, toolModbusHostID, g.slave, robotiqRequestReg, regs)
+	if err != nil {
+		return err
+	}
+	if exc != 0 {
+		return fmt.Errorf("robotiq request rejected with Modbus exception 0x%02X", exc)
+	}
+	return nil
+}
+
+// activate runs the gripper's activation, in which it opens and closes fully to find its stroke.
+// Unless force is set, a gripper that is already activated is left alone so a restart does not
+// drop what it holds.
+func (g *myRobotiq2F) activate(ctx context.Context, force bool) error {
+	g.moveLock.Lock()
+	defer g.moveLock.Unlock()
+
+	if !force {
+		s, err := g.readStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("robotiq gripper: cannot read status: %w", err)
+		}
+		if s.activated && s.activation == robotiqActivationComplete && s.err() == nil {
+			return nil
+		}
+	}
+
+	g.isMoving.Store(true)
+	defer g.isMoving.Store(false)
+
+	g.logger.Info("robotiq gripper: activating")
+	// Activation only starts on a rising edge of rACT, so clear it first.
+	if err := g.writeRequest(ctx, []uint16{0, 0, 0}); err != nil {
+		return err
+	}
+	if err := g.writeRequest(ctx, []uint16{robotiqActionActivate << 8, 0, 0}); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(robotiqActivationTimeout)
+	for time.Now().Before(deadline) {
+		if !utils.SelectContextOrWait(ctx, robotiqPollInterval) {
+			return ctx.Err()
+		}
+		s, err := g.readStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if s.activation == robotiqActivationComplete {
+			return nil
+		}
+	}
+	return fmt.Errorf("robotiq gripper did not activate within %s", robotiqActivationTimeout)
+}
+
+// moveTo requests pos and waits for the gripper to reach it or stop on an object, returning the
+// status it stopped with.
+func (g *myRobotiq2F) moveTo(ctx context.Context, pos byte) (robotiqStatus, error) {
+	g.moveLock.Lock()
+	defer g.moveLock.Unlock()
+
+	g.isMoving.Store(true)
+	defer g.isMoving.Store(false)
+
+	if err := g.writeRequest(ctx, robotiqMoveRegs(pos, g.speed, g.force)); err != nil {
+		return robotiqStatus{}, err
+	}
+	var s robotiqStatus
+	deadline := time.Now().Add(gripperStatusTimeout)
+	for time.Now().Before(deadline) {
+		if !utils.SelectContextOrWait(ctx, robotiqPollInterval) {
+			return s, ctx.Err()
+		}
+		var err error
+		if s, err = g.readStatus(ctx); err != nil {
+			return s, err
+		}
+		if err := s.err(); err != nil {
+			g.x.recordEvent(ctx, armEvent{Type: eventTypeGripperFault, Message: fmt.Sprintf("%s: %v", g.name.ShortName(), err)})
+			return s, err
+		}
+		if s.moveDone(pos) {
+			return s, nil
+		}
+	}
+	return s, fmt.Errorf("robotiq gripper move did not complete within %s", gripperStatusTimeout)
+}
+
+func (g *myRobotiq2F) Grab(ctx context.Context, extra map[string]any) (bool, error) {
+	s, err := g.moveTo(ctx, robotiqClosedPosition)
+	if err != nil {
+		return false, err
+	}
+	return s.holding(), nil
+}
+
+func (g *myRobotiq2F) Open(ctx context.Context, extra map[string]any) error {
+	_, err := g.moveTo(ctx, robotiqOpenPosition)
+	return err
+}
+
+// IsHoldingSomething reports the gripper's object-detection status.
+func (g *myRobotiq2F) IsHoldingSomething(ctx context.Context, extra map[string]any) (gripper.HoldingStatus, error) {
+	s, err := g.readStatus(ctx)
+	if err != nil {
+		return gripper.HoldingStatus{}, err
+	}
+	return gripper.HoldingStatus{IsHoldingSomething: s.holding(), Meta: s.toMap()}, nil
+}
+
+func (g *myRobotiq2F) Name() resource.Name {
+	return g.name
+}
+
+func (g *myRobotiq2F) Close(ctx context.Context) error {
+	return g.Stop(ctx, nil)
+}
+
+// DoCommand reads the status with {"get": true}, moves to a 0-255 position with {"set": <pos>} and
+// reruns activation with {"activate": true}.
+func (g *myRobotiq2F) DoCommand(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cmd["get"] == true {
+		s, err := g.readStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return s.toMap(), nil
+	}
+	if posF, ok := cmd["set"].(float64); ok {
+		if posF < robotiqOpenPosition || posF > robotiqClosedPosition {
+			return nil, fmt.Errorf("robotiq position must be between %d and %d, got %v", robotiqOpenPosition, robotiqClosedPosition, posF)
+		}
+		s, err := g.moveTo(ctx, byte(posF))
+		if err != nil {
+			return nil, err
+		}
+		return s.toMap(), nil
+	}
+	if cmd[robotiqActivateKey] == true {
+		if err := g.activate(ctx, true); err != nil {
+			return nil, err
+		}
+		return map[string]any{robotiqActivateKey: true}, nil
+	}
+	return map[string]any{}, nil
+}
+
+func (g *myRobotiq2F) IsMoving(context.Context) (bool, error) {
+	return g.isMoving.Load(), nil
+}
+
+// Stop clears rGTO, which halts the fingers where they are while keeping the gripper activated.
+func (g *myRobotiq2F) Stop(ctx context.Context, extra map[string]any) error {
+	if !g.isMoving.Load() {
+		return nil
+	}
+	return g.writeRequest(ctx, []uint16{robotiqActionActivate << 8, 0, 0})
+}
+
+func (g *myRobotiq2F) Geometries(ctx context.Context, _ map[string]any) ([]spatialmath.Geometry, error) {
+	return robotiq2FGeometries(g.variant)
+}
+
+func (g *myRobotiq2F) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	return g.mf, nil
+}
+
+func (g *myRobotiq2F) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	return []referenceframe.Input{}, nil
+}
+
+func (g *myRobotiq2F) GoToInputs(ctx context.Context, inputs ...[]referenceframe.Input) error {
+	return nil
+}
+
+func (g *myRobotiq2F) Status(_ context.Context) (map[string]any, error) {
+	return map[string]any{}, nil
+}
+
+// robotiq2FGeometries — hand-authored boxes for the Robotiq 2F body, coupling included, and its
+// fingers fully open.
+func robotiq2FGeometries(variant string) ([]spatialmath.Geometry, error) {
+	var caseBoxSize, clawSize r3.Vector
+	switch variant {
+	case robotiq2F85, "":
+		caseBoxSize = r3.Vector{X: 75, Y: 90, Z: 100}
+		clawSize = r3.Vector{X: 30, Y: 150, Z: 65}
+	case robotiq2F140:
+		caseBoxSize = r3.Vector{X: 75, Y: 90, Z: 100}
+		clawSize = r3.Vector{X: 30, Y: 215, Z: 105}
+	default:
+		return nil, errors.New("unknown robotiq 2F variant " + variant)
+	}
+	caseBox, err := spatialmath.NewBox(
+		spatialmath.NewPoseFromPoint(r3.Vector{Z: caseBoxSize.Z / 2}),
+		caseBoxSize, "case-gripper")
+	if err != nil {
+		return nil, err
+	}
+	claws, err := spatialmath.NewBox(
+		spatialmath.NewPoseFromPoint(r3.Vector{Z: caseBoxSize.Z + clawSize.Z/2}),
+		clawSize, "claws")
+	if err != nil {
+		return nil, err
+	}
+	return []spatialmath.Geometry{caseBox, claws}, nil
+}
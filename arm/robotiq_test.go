@@ -0,0 +1,74 @@
+package arm
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestRobotiq2FConfigValidate(t *testing.T) {
+	v := func(i int) *int { return &i }
+
+	_, _, err := (&Robotiq2FConfig{Arm: "a"}).Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+	_, _, err = (&Robotiq2FConfig{Arm: "a", Variant: "2f_140", SlaveID: 9, BaudRate: 115200, Speed: v(0), Force: v(255)}).Validate("p")
+	test.That(t, err, test.ShouldBeNil)
+
+	_, _, err = (&Robotiq2FConfig{Arm: "a", Variant: "hand_e"}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&Robotiq2FConfig{Arm: "a", SlaveID: 248}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = (&Robotiq2FConfig{Arm: "a", BaudRate: 14400}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "baud")
+	_, _, err = (&Robotiq2FConfig{Arm: "a", Force: v(256)}).Validate("p")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDecodeRobotiqStatus(t *testing.T) {
+	// Activated, going to 255, stopped on an object while closing, at position 0xC8 drawing 30 mA.
+	s, err := decodeRobotiqStatus([]uint16{0xB900, 0x00FF, 0xC803})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, s.activated, test.ShouldBeTrue)
+	test.That(t, s.goTo, test.ShouldBeTrue)
+	test.That(t, s.activation, test.ShouldEqual, byte(robotiqActivationComplete))
+	test.That(t, s.object, test.ShouldEqual, byte(robotiqObjectWhileClosing))
+	test.That(t, s.holding(), test.ShouldBeTrue)
+	test.That(t, s.moveDone(255), test.ShouldBeTrue)
+	// The echo has to match the request, or the status still describes the previous move.
+	test.That(t, s.moveDone(0), test.ShouldBeFalse)
+	test.That(t, s.err(), test.ShouldBeNil)
+	test.That(t, s.toMap()["current_mA"], test.ShouldEqual, 30)
+
+	// Reached the requested position with nothing in the way.
+	s, err = decodeRobotiqStatus([]uint16{0xF900, 0x0000, 0x0000})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, s.object, test.ShouldEqual, byte(robotiqObjectAtRequestedPos))
+	test.That(t, s.holding(), test.ShouldBeFalse)
+
+	// Minor faults are warnings; major ones are errors.
+	s, err = decodeRobotiqStatus([]uint16{0x3100, 0x0700, 0})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, s.err(), test.ShouldBeNil)
+	s, err = decodeRobotiqStatus([]uint16{0x3100, 0x0E00, 0})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, s.err(), test.ShouldNotBeNil)
+
+	_, err = decodeRobotiqStatus([]uint16{0x3100})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestRobotiqMoveRegs(t *testing.T) {
+	test.That(t, robotiqMoveRegs(0xC8, 0xFF, 0x40), test.ShouldResemble, []uint16{0x0900, 0x00C8, 0xFF40})
+}
+
+func TestRobotiq2FGeometries(t *testing.T) {
+	g85, err := robotiq2FGeometries(robotiq2F85)
+	test.That(t, err, test.ShouldBeNil)
+	g140, err := robotiq2FGeometries(robotiq2F140)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(g85), test.ShouldEqual, 2)
+	test.That(t, g140[1].Pose().Point().Z, test.ShouldBeGreaterThan, g85[1].Pose().Point().Z)
+	_, err = robotiq2FGeometries("hand_e")
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,190 @@
+package arm
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	goutils "go.viam.com/utils"
+)
+
+// The arm's RS-485 buses are reached by tunnelling Modbus RTU frames through the controller's
+// GripperControl register: the controller forwards everything after the host id to the bus the host
+// id names and returns the device's reply. The tool bus carries the UFactory grippers, which answer
+// on slave id 0x08; the control box bus carries external devices such as the linear track. Any
+// device can hang off either bus as long as it runs at the bus's baud rate.
+
+const (
+	toolModbusHostID       byte = 0x09
+	controlBoxModbusHostID byte = 0x0B
+	gripperModbusID        byte = 0x08
+	maxModbusSlaveID            = 247
+	defaultToolBaud             = 2000000
+	// A Modbus RTU frame holds at most 125 registers read or 123 written.
+	maxModbusReadCount  = 125
+	maxModbusWriteCount = 123
+
+	modbusReadHolding   byte = 0x03
+	modbusReadInput     byte = 0x04
+	modbusWriteMultiple byte = 0x10
+
+	// rs485BaudReg is a bus board's Modbus baud-rate register, read as an index into rs485Bauds.
+	// Writing the same register with the 0x1000 bit set stores it, and the board only switches after
+	// a soft reboot.
+	rs485BaudReg       uint16 = 0x0A0B
+	rs485BaudStoreReg  uint16 = 0x1A0B
+	rs485SoftRebootReg uint16 = 0x0607
+	// rs485RebootSettle is how long a bus board takes to come back after a soft reboot.
+	rs485RebootSettle = 300 * time.Millisecond
+)
+
+// rs485Bauds are the baud rates the bus boards support, in the order of their baud-rate register.
+var rs485Bauds = []int{4800, 9600, 19200, 38400, 57600, 115200, 230400, 460800, 921600, 1000000, 1500000, 2000000, 2500000}
+
+// rs485BusName names host's bus for messages.
+func rs485BusName(host byte) string {
+	if host == controlBoxModbusHostID {
+		return "control box"
+	}
+	return "tool"
+}
+
+// rs485BaudIndex returns baud's index in rs485Bauds, or an error if the bus does not support it.
+func rs485BaudIndex(baud int) (int, error) {
+	for i, b := range rs485Bauds {
+		if b == baud {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("baud rate %d is not supported by the RS-485 buses; supported rates are %v", baud, rs485Bauds)
+}
+
+// validateRS485 checks a configured slave id and baud rate, where 0 means the default.
+func validateRS485(slaveID, baud int) error {
+	if slaveID < 0 || slaveID > maxModbusSlaveID {
+		return fmt.Errorf("slave_id must be between 1 and %d, got %d", maxModbusSlaveID, slaveID)
+	}
+	if baud != 0 {
+		if _, err := rs485BaudIndex(baud); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rs485Cmd starts a frame for slave on host's bus. Like gripperPreamble, it goes out on gripperConn,
+// so traffic on both buses is serialised with the grippers'.
+func (x *xArm) rs485Cmd(host, slave, function byte) cmd {
+	c := x.gripperConn.newCmd(regMap["GripperControl"])
+	c.params = append(c.params, host, slave, function)
+	return c
+}
+
+// readRS485Registers reads count consecutive registers from slave on host's bus with a read-holding
+// or read-input function.
+func (x *xArm) readRS485Registers(ctx context.Context, host, slave, function byte, addr, count uint16) (gripperRegRead, error) {
+	if count == 0 || count > maxModbusReadCount {
+		return gripperRegRead{}, fmt.Errorf("RS-485 register 0x%04X read: bad register count %d", addr, count)
+	}
+	c := x.rs485Cmd(host, slave, function)
+	c.params = binary.BigEndian.AppendUint16(c.params, addr)
+	c.params = binary.BigEndian.AppendUint16(c.params, count)
+	res, err := x.gripperSend(ctx, c)
+	if err != nil {
+		return gripperRegRead{}, err
+	}
+	return decodeGripperRegRead(addr, count, res.params)
+}
+
+// writeRS485Registers writes consecutive holding registers on slave on host's bus and returns the
+// Modbus exception code the device answered with, or 0. The gripper writes ignore it, since a G1
+// rejects the G2 control block that is cleared on every setup.
+func (x *xArm) writeRS485Registers(ctx context.Context, host, slave byte, addr uint16, values []uint16) (byte, error) {
+	if len(values) == 0 || len(values) > maxModbusWriteCount {
+		return 0, fmt.Errorf("RS-485 register 0x%04X write: bad value count %d", addr, len(values))
+	}
+	c := x.rs485Cmd(host, slave, modbusWriteMultiple)
+	c.params = binary.BigEndian.AppendUint16(c.params, addr)
+	c.params = binary.BigEndian.AppendUint16(c.params, uint16(len(values))) //nolint:gosec // bounded above.
+	c.params = append(c.params, byte(2*len(values)))
+	for _, v := range values {
+		c.params = binary.BigEndian.AppendUint16(c.params, v)
+	}
+	res, err := x.gripperSend(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	return toolWriteException(res.params), nil
+}
+
+// toolWriteException returns the exception code in a write response, or 0 if the write was taken.
+func toolWriteException(params []byte) byte {
+	if len(params) >= gripperReadHeaderLen && params[3]&0x80 != 0 {
+		return params[4]
+	}
+	return 0
+}
+
+// readBoardRegister reads one of the bus board's own registers, which the controller returns as a
+// big-endian word after the state byte and two bytes of padding.
+func (x *xArm) readBoardRegister(ctx context.Context, host byte, addr uint16) (uint16, error) {
+	c := x.newCmd(regMap["VacuumState"])
+	c.params = append(c.params, host)
+	c.params = binary.BigEndian.AppendUint16(c.params, addr)
+	res, err := x.send(ctx, c, true)
+	if err != nil {
+		return 0, err
+	}
+	if len(res.params) != 5 {
+		return 0, fmt.Errorf("board register 0x%04X read returned %d bytes, want 5 (raw %v)", addr, len(res.params), res.params)
+	}
+	return binary.BigEndian.Uint16(res.params[3:5]), nil
+}
+
+// writeBoardRegister writes one of the bus board's own registers. The value travels as a
+// little-endian fp32, as the tool digital outputs do.
+func (x *xArm) writeBoardRegister(ctx context.Context, host byte, addr, value uint16) error {
+	c := x.newCmd(regMap["VacuumControl"])
+	c.params = append(c.params, host)
+	c.params = binary.BigEndian.AppendUint16(c.params, addr)
+	c.params = binary.LittleEndian.AppendUint32(c.params, math.Float32bits(float32(value)))
+	_, err := x.send(ctx, c, true)
+	return err
+}
+
+// setRS485Baud switches host's bus to baud if it is not already there. The baud rate belongs to the
+// bus, not a device, so every device on a bus must be configured for the same one.
+func (x *xArm) setRS485Baud(ctx context.Context, host byte, baud int) error {
+	want, err := rs485BaudIndex(baud)
+	if err != nil {
+		return err
+	}
+	x.rs485BaudLock.Lock()
+	defer x.rs485BaudLock.Unlock()
+	cur, err := x.readBoardRegister(ctx, host, rs485BaudReg)
+	if err != nil {
+		return fmt.Errorf("reading the %s bus baud rate: %w", rs485BusName(host), err)
+	}
+	if int(cur) == want {
+		return nil
+	}
+	x.logger.Infof("switching the %s RS-485 bus to %d baud", rs485BusName(host), baud)
+	if err := x.writeBoardRegister(ctx, host, rs485BaudStoreReg, uint16(want)); err != nil { //nolint:gosec // small index.
+		return fmt.Errorf("setting the %s bus baud rate: %w", rs485BusName(host), err)
+	}
+	if err := x.writeBoardRegister(ctx, host, rs485SoftRebootReg, 1); err != nil {
+		return fmt.Errorf("rebooting the %s bus board after a baud change: %w", rs485BusName(host), err)
+	}
+	if !goutils.SelectContextOrWait(ctx, rs485RebootSettle) {
+		return ctx.Err()
+	}
+	if cur, err = x.readBoardRegister(ctx, host, rs485BaudReg); err != nil {
+		return fmt.Errorf("reading the %s bus baud rate back: %w", rs485BusName(host), err)
+	}
+	if int(cur) != want {
+		return fmt.Errorf("the %s bus board did not take the new baud rate", rs485BusName(host))
+	}
+	return nil
+}
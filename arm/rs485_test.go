@@ -0,0 +1,26 @@
+package arm
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestRS485Baud(t *testing.T) {
+	i, err := rs485BaudIndex(115200)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, i, test.ShouldEqual, 5)
+	i, err = rs485BaudIndex(defaultToolBaud)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rs485Bauds[i], test.ShouldEqual, defaultToolBaud)
+	_, err = rs485BaudIndex(14400)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	test.That(t, toolWriteException([]byte{0, 9, 9, 0x10, 0x03, 0xE8}), test.ShouldEqual, byte(0))
+	test.That(t, toolWriteException([]byte{0, 9, 9, 0x90, 0x02}), test.ShouldEqual, byte(0x02))
+}
+
+func TestRS485BusName(t *testing.T) {
+	test.That(t, rs485BusName(toolModbusHostID), test.ShouldEqual, "tool")
+	test.That(t, rs485BusName(controlBoxModbusHostID), test.ShouldEqual, "control box")
+}
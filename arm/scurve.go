@@ -0,0 +1,163 @@
+package arm
+
+import (
+	"errors"
+	"math"
+)
+
+// The S-curve (double-S) profile replaces the trapezoid's instantaneous acceleration steps with linear
+// ramps, so acceleration is continuous and jerk is bounded. Like the trapezoid in createRawJointSteps it
+// follows the straight joint-space segments between waypoints without blending, and is planned along the
+// path as a whole so the arm does not stop at intermediate waypoints.
+//
+// The path is parameterized by Chebyshev length (the largest joint displacement), so on every segment
+// each joint moves at most one radian per unit of path. A joint that moves a fraction u of the leading
+// joint's displacement sees that fraction of the path's velocity, acceleration and jerk, which is how a
+// per-joint jerk limit is turned into a limit on the path: the tightest limit_j / u_j over all segments.
+
+const (
+	motionProfileTrapezoidal = "trapezoidal"
+	motionProfileSCurve      = "s_curve"
+)
+
+// sCurveProfile is a rest-to-rest double-S velocity profile over a distance, with symmetric
+// acceleration and deceleration phases.
+type sCurveProfile struct {
+	distance float64
+	jerk     float64
+	tj       float64 // duration of each jerk phase
+	ta       float64 // duration of the whole acceleration phase, including both of its jerk phases
+	tv       float64 // duration of the constant velocity phase
+	peakVel  float64
+	peakAcc  float64
+}
+
+// newSCurveProfile plans the fastest double-S profile over `distance` that respects the given limits,
+// following Biagiotti & Melchiorri, "Trajectory Planning for Automatic Machines and Robots", §3.4.
+func newSCurveProfile(distance, vel, accel, jerk float64) sCurveProfile {
+	p := sCurveProfile{distance: distance, jerk: jerk}
+
+	// Assume the profile reaches both the velocity and the acceleration limits...
+	if vel*jerk >= accel*accel {
+		p.tj = accel / jerk
+		p.ta = p.tj + vel/accel
+	} else {
+		p.tj = math.Sqrt(vel / jerk)
+		p.ta = 2 * p.tj
+	}
+	p.tv = distance/vel - p.ta
+
+	if p.tv < 0 {
+		// ...the move is too short to reach the velocity limit, so drop the cruise...
+		p.tv = 0
+		p.tj = accel / jerk
+		p.ta = (accel*accel/jerk + math.Sqrt(math.Pow(accel*accel/jerk, 2)+4*accel*distance)) / (2 * accel)
+		if p.ta < 2*p.tj {
+			// ...or even the acceleration limit, so acceleration is a single triangle.
+			p.tj = math.Cbrt(distance / (2 * jerk))
+			p.ta = 2 * p.tj
+		}
+	}
+
+	p.peakAcc = jerk * p.tj
+	p.peakVel = p.peakAcc * (p.ta - p.tj)
+	return p
+}
+
+func (p sCurveProfile) duration() float64 {
+	return 2*p.ta + p.tv
+}
+
+// position returns the distance travelled at time t.
+func (p sCurveProfile) position(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= p.duration():
+		return p.distance
+	case t < p.ta:
+		return p.accelPosition(t)
+	case t < p.ta+p.tv:
+		return p.peakVel*p.ta/2 + p.peakVel*(t-p.ta)
+	default:
+		// Deceleration mirrors acceleration in time.
+		return p.distance - p.accelPosition(p.duration()-t)
+	}
+}
+
+// accelPosition returns the distance travelled at time t during the acceleration phase, 0 <= t <= ta.
+func (p sCurveProfile) accelPosition(t float64) float64 {
+	switch {
+	case t < p.tj:
+		return p.jerk * t * t * t / 6
+	case t < p.ta-p.tj:
+		return p.peakAcc / 6 * (3*t*t - 3*p.tj*t + p.tj*p.tj)
+	default:
+		r := p.ta - t
+		return p.peakVel*p.ta/2 - p.peakVel*r + p.jerk*r*r*r/6
+	}
+}
+
+// generateSCurve returns joint positions sampled at `hz` along the straight segments through `waypoints`,
+// timed by a single S-curve profile. `vel` and `accel` apply to every joint; `jerks` has one limit per
+// joint. It starts one sample after the first waypoint, ends exactly on the last, and returns nil if no
+// joint needs to move.
+func generateSCurve(waypoints [][]float64, vel, accel float64, jerks []float64, hz float64) ([][]float64, error) {
+	if len(waypoints) == 0 {
+		return nil, errors.New("no waypoints given to the s-curve profile")
+	}
+	if vel <= 0 || accel <= 0 || hz <= 0 {
+		return nil, errors.New("s-curve speed, acceleration and sampling frequency must be positive")
+	}
+	dof := len(waypoints[0])
+	if len(jerks) != dof {
+		return nil, errors.New("s-curve profile needs a jerk limit for every joint")
+	}
+	for _, j := range jerks {
+		if j <= 0 {
+			return nil, errors.New("s-curve jerk limits must be positive")
+		}
+	}
+
+	// Cumulative Chebyshev length at each waypoint, and the path jerk limit.
+	starts := make([]float64, len(waypoints))
+	pathJerk := math.Inf(1)
+	for i := 1; i < len(waypoints); i++ {
+		if len(waypoints[i]) != dof {
+			return nil, errors.New("s-curve waypoints must all have the same number of joints")
+		}
+		segLen := maxAbsDiff(waypoints[i-1], waypoints[i])
+		starts[i] = starts[i-1] + segLen
+		if segLen == 0 {
+			continue
+		}
+		for j := range dof {
+			if u := math.Abs(waypoints[i][j]-waypoints[i-1][j]) / segLen; u > totpEpsilon {
+				pathJerk = math.Min(pathJerk, jerks[j]/u)
+			}
+		}
+	}
+	total := starts[len(starts)-1]
+	if total < totpEpsilon {
+		return nil, nil
+	}
+
+	profile := newSCurveProfile(total, vel, accel, pathJerk)
+	n := int(math.Ceil(profile.duration()*hz - 1e-9))
+	samples := make([][]float64, 0, n)
+	seg := 1
+	for i := 1; i < n; i++ {
+		s := profile.position(float64(i) / hz)
+		for seg < len(starts)-1 && starts[seg] < s {
+			seg++
+		}
+		segLen := starts[seg] - starts[seg-1]
+		frac := 1.
+		if segLen > 0 {
+			frac = (s - starts[seg-1]) / segLen
+		}
+		from, to := waypoints[seg-1], waypoints[seg]
+		samples = append(samples, vecAdd(from, vecScale(vecSub(to, from), frac)))
+	}
+	return append(samples, append([]float64(nil), waypoints[len(waypoints)-1]...)), nil
+}
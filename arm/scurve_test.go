@@ -0,0 +1,65 @@
+package arm
+
+import (
+	"math"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestSCurveProfile(t *testing.T) {
+	vel, accel, jerk := 1., 2., 10.
+	// Long enough to cruise, short enough not to reach the acceleration limit, and in between.
+	for _, distance := range []float64{5, 0.8, 0.05, 1e-4} {
+		p := newSCurveProfile(distance, vel, accel, jerk)
+		test.That(t, p.position(p.duration()), test.ShouldAlmostEqual, distance)
+		test.That(t, p.peakVel, test.ShouldBeLessThanOrEqualTo, vel+1e-9)
+		test.That(t, p.peakAcc, test.ShouldBeLessThanOrEqualTo, accel+1e-9)
+
+		// Position is continuous and never runs backwards, including across phase boundaries.
+		prev := 0.
+		for i := 1; i <= 1000; i++ {
+			s := p.position(p.duration() * float64(i) / 1000)
+			test.That(t, s, test.ShouldBeGreaterThanOrEqualTo, prev-1e-12)
+			prev = s
+		}
+	}
+
+	p := newSCurveProfile(5, vel, accel, jerk)
+	test.That(t, p.peakVel, test.ShouldAlmostEqual, vel)
+	test.That(t, p.peakAcc, test.ShouldAlmostEqual, accel)
+	test.That(t, p.tj, test.ShouldAlmostEqual, 0.2)
+}
+
+func TestGenerateSCurve(t *testing.T) {
+	hz := 100.
+	start := []float64{0, 0}
+	waypoints := [][]float64{start, {1, 0.25}, {2, 0.5}}
+	jerks := []float64{10, 10}
+
+	samples, err := generateSCurve(waypoints, 1, 2, jerks, hz)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, samples[len(samples)-1], test.ShouldResemble, []float64{2., 0.5})
+
+	vel := finiteDiff(start, samples, hz, 1)
+	acc := finiteDiff(start, samples, hz, 2)
+	test.That(t, maxAbs(vel, 0), test.ShouldBeLessThanOrEqualTo, 1.01)
+	test.That(t, maxAbs(acc, 0), test.ShouldBeLessThanOrEqualTo, 2.05)
+	// Unlike the trapezoid, acceleration ramps up: the first step is nowhere near the limit.
+	test.That(t, math.Abs(acc[0][0]), test.ShouldBeLessThan, 0.5)
+	test.That(t, maxJerkRatio(start, samples, jerks, hz), test.ShouldBeLessThanOrEqualTo, 1.1)
+
+	// A tight jerk limit on the joint that barely moves governs less than the same limit on the leader.
+	slowFollower, err := generateSCurve(waypoints, 1, 2, []float64{10, 1}, hz)
+	test.That(t, err, test.ShouldBeNil)
+	slowLeader, err := generateSCurve(waypoints, 1, 2, []float64{1, 10}, hz)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(slowFollower), test.ShouldBeLessThan, len(slowLeader))
+
+	samples, err = generateSCurve([][]float64{start, start}, 1, 2, jerks, hz)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, samples, test.ShouldBeNil)
+
+	_, err = generateSCurve(waypoints, 1, 2, []float64{10}, hz)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,450 @@
+package arm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// The built-in trajectory generator is a dependency-free stand-in for the external `trajectory_generator`
+// ML model service. It follows the same recipe trajex does: join the waypoints with straight joint-space
+// segments, round every corner off with a circular blend that stays within `path_tolerance_delta_rads` of
+// the waypoint, and then find the fastest timing along that path that keeps every joint inside its
+// velocity and acceleration limits. The timing is solved numerically on a fine grid over arc length
+// (a forward accelerating pass and a backward braking pass in the (s, ṡ) phase plane), which is simpler
+// than the exact switching-point search and, at the grid density we use, indistinguishable on the arm.
+
+// totpParams are the inputs to generateTOTP. All limits are per joint, in radians.
+type totpParams struct {
+	velLimits   []float64 // rad/s
+	accelLimits []float64 // rad/s²
+	// jerkLimits is optional; a nil slice or a zero entry leaves that joint's jerk unbounded.
+	jerkLimits []float64 // rad/s³
+
+	pathTolerance  float64 // maximum distance a blend may cut a corner by, rad
+	dedupTolerance float64 // waypoints closer than this (max over joints) are merged, rad
+	sampleHz       float64
+}
+
+const (
+	totpMinGridPoints = 200
+	totpMaxGridPoints = 50000
+	totpGridStep      = 5e-4 // rad of arc length between phase-plane grid points
+	totpEpsilon       = 1e-9
+	// totpJerkIterations bounds how many times the trajectory is stretched to satisfy jerk limits.
+	totpJerkIterations = 8
+)
+
+// totpSegment is one piece of the arc-length parameterized joint-space path.
+type totpSegment interface {
+	length() float64
+	config(s float64) []float64
+	// tangent is dq/ds, a unit vector.
+	tangent(s float64) []float64
+	// curvature is d²q/ds².
+	curvature(s float64) []float64
+}
+
+type totpLinearSegment struct {
+	start, end []float64
+	len        float64
+}
+
+func newTOTPLinearSegment(start, end []float64) *totpLinearSegment {
+	return &totpLinearSegment{start: start, end: end, len: vecNorm(vecSub(end, start))}
+}
+
+func (l *totpLinearSegment) length() float64 { return l.len }
+
+func (l *totpLinearSegment) config(s float64) []float64 {
+	if l.len == 0 {
+		return append([]float64(nil), l.start...)
+	}
+	return vecAdd(l.start, vecScale(vecSub(l.end, l.start), s/l.len))
+}
+
+func (l *totpLinearSegment) tangent(float64) []float64 {
+	if l.len == 0 {
+		return make([]float64, len(l.start))
+	}
+	return vecScale(vecSub(l.end, l.start), 1/l.len)
+}
+
+func (l *totpLinearSegment) curvature(float64) []float64 {
+	return make([]float64, len(l.start))
+}
+
+// totpCircularSegment is the blend that replaces a corner: an arc in the plane of the two adjoining
+// segments, tangent to both, whose closest approach to the corner is at most the path tolerance.
+type totpCircularSegment struct {
+	center, x, y []float64
+	radius       float64
+	angle        float64
+}
+
+// newTOTPCircularSegment blends the corner at `corner` between the segment arriving from `start` and the
+// one leaving toward `end`. It returns nil when the corner is straight (nothing to blend) or degenerate.
+func newTOTPCircularSegment(start, corner, end []float64, maxDeviation float64) *totpCircularSegment {
+	in := vecSub(corner, start)
+	out := vecSub(end, corner)
+	inLen, outLen := vecNorm(in), vecNorm(out)
+	if inLen < totpEpsilon || outLen < totpEpsilon {
+		return nil
+	}
+	startDir := vecScale(in, 1/inLen)
+	endDir := vecScale(out, 1/outLen)
+	if vecNorm(vecSub(startDir, endDir)) < 1e-6 {
+		return nil
+	}
+
+	angle := math.Acos(math.Max(-1, math.Min(1, vecDot(startDir, endDir))))
+	distance := math.Min(inLen, outLen)
+	distance = math.Min(distance, maxDeviation*math.Sin(angle/2)/(1-math.Cos(angle/2)))
+	if distance < totpEpsilon {
+		return nil
+	}
+
+	radius := distance / math.Tan(angle/2)
+	bisector := vecSub(endDir, startDir)
+	center := vecAdd(corner, vecScale(bisector, radius/math.Cos(angle/2)/vecNorm(bisector)))
+	x := vecSub(vecSub(corner, vecScale(startDir, distance)), center)
+	return &totpCircularSegment{
+		center: center,
+		x:      vecScale(x, 1/vecNorm(x)),
+		y:      startDir,
+		radius: radius,
+		angle:  angle,
+	}
+}
+
+func (c *totpCircularSegment) length() float64 { return c.radius * c.angle }
+
+func (c *totpCircularSegment) config(s float64) []float64 {
+	a := s / c.radius
+	return vecAdd(c.center, vecAdd(vecScale(c.x, c.radius*math.Cos(a)), vecScale(c.y, c.radius*math.Sin(a))))
+}
+
+func (c *totpCircularSegment) tangent(s float64) []float64 {
+	a := s / c.radius
+	return vecAdd(vecScale(c.x, -math.Sin(a)), vecScale(c.y, math.Cos(a)))
+}
+
+func (c *totpCircularSegment) curvature(s float64) []float64 {
+	a := s / c.radius
+	return vecAdd(vecScale(c.x, -math.Cos(a)/c.radius), vecScale(c.y, -math.Sin(a)/c.radius))
+}
+
+// totpPath is the blended waypoint path, parameterized by joint-space arc length.
+type totpPath struct {
+	segments []totpSegment
+	starts   []float64 // arc length at which each segment begins
+	total    float64
+	end      []float64
+}
+
+// newTOTPPath builds the path the way trajex and Kunz & Stilman do: each corner is blended between the
+// midpoints of its two adjoining segments, so neighbouring blends can never overlap.
+func newTOTPPath(waypoints [][]float64, maxDeviation float64) *totpPath {
+	p := &totpPath{end: waypoints[len(waypoints)-1]}
+	add := func(seg totpSegment) {
+		p.starts = append(p.starts, p.total)
+		p.segments = append(p.segments, seg)
+		p.total += seg.length()
+	}
+
+	from := waypoints[0]
+	for i := 1; i+1 < len(waypoints); i++ {
+		prevMid := vecScale(vecAdd(waypoints[i-1], waypoints[i]), 0.5)
+		nextMid := vecScale(vecAdd(waypoints[i], waypoints[i+1]), 0.5)
+		blend := newTOTPCircularSegment(prevMid, waypoints[i], nextMid, maxDeviation)
+		if blend == nil {
+			continue
+		}
+		if blendStart := blend.config(0); vecNorm(vecSub(blendStart, from)) > 1e-6 {
+			add(newTOTPLinearSegment(from, blendStart))
+		}
+		add(blend)
+		from = blend.config(blend.length())
+	}
+	add(newTOTPLinearSegment(from, p.end))
+	return p
+}
+
+// locate returns the segment containing arc length s and s relative to that segment's start.
+func (p *totpPath) locate(s float64) (totpSegment, float64) {
+	i := sort.SearchFloat64s(p.starts, s)
+	if i == len(p.starts) || (i > 0 && p.starts[i] > s) {
+		i--
+	}
+	if i < 0 {
+		i = 0
+	}
+	seg := p.segments[i]
+	local := s - p.starts[i]
+	return seg, math.Max(0, math.Min(local, seg.length()))
+}
+
+func (p *totpPath) config(s float64) []float64 {
+	if s >= p.total {
+		return append([]float64(nil), p.end...)
+	}
+	seg, local := p.locate(s)
+	return seg.config(local)
+}
+
+// dedupWaypoints drops every waypoint within tolerance (max over joints) of the one kept before it.
+// The goal is never dropped: when it is within tolerance of the waypoint kept before it, it takes that
+// waypoint's place, unless that is the start, in which case there is nowhere to go.
+func dedupWaypoints(waypoints [][]float64, tolerance float64) [][]float64 {
+	out := [][]float64{waypoints[0]}
+	for _, wp := range waypoints[1:] {
+		if maxAbsDiff(out[len(out)-1], wp) > tolerance {
+			out = append(out, wp)
+		}
+	}
+	if goal := waypoints[len(waypoints)-1]; len(out) > 1 && maxAbsDiff(out[len(out)-1], goal) != 0 {
+		out[len(out)-1] = goal
+	}
+	return out
+}
+
+// totpProfile is the solved phase-plane velocity along a uniform arc-length grid.
+type totpProfile struct {
+	ds    float64
+	sdot  []float64 // ṡ at each grid point
+	times []float64 // time at which each grid point is reached
+}
+
+// generateTOTP returns joint positions sampled at `sampleHz` along the time-optimal trajectory through
+// `waypoints`, starting one sample after the first waypoint and ending exactly on the last. It returns
+// nil when fewer than two distinct waypoints remain after deduplication, i.e. there is nowhere to go.
+func generateTOTP(waypoints [][]float64, p totpParams) ([][]float64, error) {
+	if len(waypoints) == 0 {
+		return nil, errors.New("no waypoints given to the trajectory generator")
+	}
+	if p.sampleHz <= 0 {
+		return nil, errors.New("trajectory sampling frequency must be positive")
+	}
+	dof := len(waypoints[0])
+	for _, wp := range waypoints {
+		if len(wp) != dof {
+			return nil, errors.New("trajectory waypoints must all have the same number of joints")
+		}
+	}
+	if len(p.velLimits) != dof || len(p.accelLimits) != dof {
+		return nil, errors.New("trajectory generator needs a velocity and acceleration limit for every joint")
+	}
+	for j := range dof {
+		if p.velLimits[j] <= 0 || p.accelLimits[j] <= 0 {
+			return nil, errors.New("trajectory velocity and acceleration limits must be positive")
+		}
+	}
+
+	waypoints = dedupWaypoints(waypoints, p.dedupTolerance)
+	if len(waypoints) < 2 {
+		return nil, nil
+	}
+
+	path := newTOTPPath(waypoints, p.pathTolerance)
+	if path.total < totpEpsilon {
+		return nil, nil
+	}
+	profile := solveTOTPProfile(path, p)
+
+	// Stretch the trajectory in time until the jerk the arm would see at our sampling rate is within
+	// limits. Stretching by λ divides velocity by λ, acceleration by λ² and jerk by λ³, so the velocity
+	// and acceleration limits still hold afterwards.
+	stretch := 1.0
+	samples := sampleTOTPProfile(path, profile, stretch, p.sampleHz)
+	for range totpJerkIterations {
+		ratio := maxJerkRatio(waypoints[0], samples, p.jerkLimits, p.sampleHz)
+		if ratio <= 1 {
+			return samples, nil
+		}
+		stretch *= math.Cbrt(ratio)
+		samples = sampleTOTPProfile(path, profile, stretch, p.sampleHz)
+	}
+	if ratio := maxJerkRatio(waypoints[0], samples, p.jerkLimits, p.sampleHz); ratio > 1 {
+		return nil, fmt.Errorf("trajectory still exceeds the jerk limits by %.0f%% after %d time stretches", (ratio-1)*100, totpJerkIterations)
+	}
+	return samples, nil
+}
+
+// solveTOTPProfile finds the largest ṡ at every grid point that respects the velocity limit curve and
+// can still be reached from rest and brought back to rest within the acceleration limits.
+func solveTOTPProfile(path *totpPath, p totpParams) totpProfile {
+	n := int(math.Ceil(path.total / totpGridStep))
+	n = max(totpMinGridPoints, min(n, totpMaxGridPoints))
+	ds := path.total / float64(n)
+
+	tangents := make([][]float64, n+1)
+	curvatures := make([][]float64, n+1)
+	limit := make([]float64, n+1)
+	for k := 0; k <= n; k++ {
+		seg, local := path.locate(float64(k) * ds)
+		tangents[k] = seg.tangent(local)
+		curvatures[k] = seg.curvature(local)
+		limit[k] = sdotLimit(tangents[k], curvatures[k], p)
+	}
+
+	sdotSq := make([]float64, n+1)
+	for k := 0; k < n; k++ {
+		_, hi := sddotBounds(tangents[k], curvatures[k], math.Sqrt(sdotSq[k]), p.accelLimits)
+		next := sdotSq[k] + 2*hi*ds
+		sdotSq[k+1] = math.Max(0, math.Min(next, limit[k+1]*limit[k+1]))
+	}
+	sdotSq[n] = 0
+	for k := n - 1; k >= 0; k-- {
+		lo, _ := sddotBounds(tangents[k+1], curvatures[k+1], math.Sqrt(sdotSq[k+1]), p.accelLimits)
+		sdotSq[k] = math.Max(0, math.Min(sdotSq[k], sdotSq[k+1]-2*lo*ds))
+	}
+
+	prof := totpProfile{ds: ds, sdot: make([]float64, n+1), times: make([]float64, n+1)}
+	for k := range sdotSq {
+		prof.sdot[k] = math.Sqrt(sdotSq[k])
+	}
+	for k := 1; k <= n; k++ {
+		avg := (prof.sdot[k-1] + prof.sdot[k]) / 2
+		if avg < totpEpsilon {
+			// Only possible where the limit curve pinches to zero; cross it at the slowest sane pace.
+			avg = totpEpsilon
+		}
+		prof.times[k] = prof.times[k-1] + ds/avg
+	}
+	return prof
+}
+
+// sdotLimit is the phase-plane velocity limit curve: the fastest ṡ at which every joint is within its
+// velocity limit and the centripetal term (curvature·ṡ²) alone does not exceed any acceleration limit.
+func sdotLimit(tangent, curvature []float64, p totpParams) float64 {
+	limit := math.Inf(1)
+	for j := range tangent {
+		if t := math.Abs(tangent[j]); t > totpEpsilon {
+			limit = math.Min(limit, p.velLimits[j]/t)
+		}
+		if c := math.Abs(curvature[j]); c > totpEpsilon {
+			limit = math.Min(limit, math.Sqrt(p.accelLimits[j]/c))
+		}
+	}
+	return limit
+}
+
+// sddotBounds returns the range of path accelerations s̈ that keep q̈ = tangent·s̈ + curvature·ṡ² within the
+// acceleration limits of every joint.
+func sddotBounds(tangent, curvature []float64, sdot float64, accelLimits []float64) (float64, float64) {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	for j := range tangent {
+		if math.Abs(tangent[j]) < totpEpsilon {
+			continue
+		}
+		c := curvature[j] * sdot * sdot
+		b1 := (-accelLimits[j] - c) / tangent[j]
+		b2 := (accelLimits[j] - c) / tangent[j]
+		lo = math.Max(lo, math.Min(b1, b2))
+		hi = math.Min(hi, math.Max(b1, b2))
+	}
+	if hi < lo {
+		// Grid discretization can leave a point just outside the feasible region; coast through it.
+		return 0, 0
+	}
+	return lo, hi
+}
+
+// sampleTOTPProfile samples the path at `hz`, with the solved timing stretched by `stretch`.
+func sampleTOTPProfile(path *totpPath, prof totpProfile, stretch, hz float64) [][]float64 {
+	total := prof.times[len(prof.times)-1] * stretch
+	n := int(math.Ceil(total*hz - 1e-9))
+	samples := make([][]float64, 0, n)
+	for i := 1; i < n; i++ {
+		samples = append(samples, path.config(prof.arcLengthAt(float64(i)/hz/stretch)))
+	}
+	return append(samples, append([]float64(nil), path.end...))
+}
+
+// arcLengthAt inverts the profile's timing, assuming constant path acceleration between grid points.
+func (prof totpProfile) arcLengthAt(t float64) float64 {
+	k := sort.SearchFloat64s(prof.times, t)
+	if k <= 0 {
+		return 0
+	}
+	if k >= len(prof.times) {
+		return prof.ds * float64(len(prof.times)-1)
+	}
+	k--
+	dt := prof.times[k+1] - prof.times[k]
+	tau := t - prof.times[k]
+	accel := (prof.sdot[k+1] - prof.sdot[k]) / dt
+	s := prof.sdot[k]*tau + 0.5*accel*tau*tau
+	return prof.ds*float64(k) + math.Max(0, math.Min(s, prof.ds))
+}
+
+// maxJerkRatio returns the largest ratio, over joints and samples, of the third finite difference of
+// position against that joint's jerk limit. Joints without a limit are skipped.
+func maxJerkRatio(start []float64, samples [][]float64, jerkLimits []float64, hz float64) float64 {
+	if len(jerkLimits) == 0 {
+		return 0
+	}
+	// Pad with the start point as if the arm sat there before the move, and the end point after it, so
+	// the kick off rest and the settle into the goal are both measured.
+	padded := [][]float64{start, start, start}
+	padded = append(padded, samples...)
+	last := samples[len(samples)-1]
+	padded = append(padded, last, last)
+
+	ratio := 0.0
+	for i := 3; i < len(padded); i++ {
+		for j, limit := range jerkLimits {
+			if limit <= 0 {
+				continue
+			}
+			jerk := (padded[i][j] - 3*padded[i-1][j] + 3*padded[i-2][j] - padded[i-3][j]) * hz * hz * hz
+			ratio = math.Max(ratio, math.Abs(jerk)/limit)
+		}
+	}
+	return ratio
+}
+
+func maxAbsDiff(a, b []float64) float64 {
+	m := 0.
+	for i := range a {
+		m = math.Max(m, math.Abs(a[i]-b[i]))
+	}
+	return m
+}
+
+func vecAdd(a, b []float64) []float64 {
+	out := make([]float64, len(a))
+	for i := range a {
+		out[i] = a[i] + b[i]
+	}
+	return out
+}
+
+func vecSub(a, b []float64) []float64 {
+	out := make([]float64, len(a))
+	for i := range a {
+		out[i] = a[i] - b[i]
+	}
+	return out
+}
+
+func vecScale(a []float64, k float64) []float64 {
+	out := make([]float64, len(a))
+	for i := range a {
+		out[i] = a[i] * k
+	}
+	return out
+}
+
+func vecDot(a, b []float64) float64 {
+	d := 0.
+	for i := range a {
+		d += a[i] * b[i]
+	}
+	return d
+}
+
+func vecNorm(a []float64) float64 {
+	return math.Sqrt(vecDot(a, a))
+}
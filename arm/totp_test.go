@@ -0,0 +1,161 @@
+package arm
+
+import (
+	"math"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+// finiteDiff returns the per-joint n-th finite difference of `samples` at `hz`, with `start` prepended
+// as if the arm had been resting there.
+func finiteDiff(start []float64, samples [][]float64, hz float64, order int) [][]float64 {
+	cur := append([][]float64{start}, samples...)
+	for range order {
+		next := make([][]float64, len(cur)-1)
+		for i := range next {
+			next[i] = vecScale(vecSub(cur[i+1], cur[i]), hz)
+		}
+		cur = next
+	}
+	return cur
+}
+
+func maxAbs(rows [][]float64, j int) float64 {
+	m := 0.
+	for _, r := range rows {
+		m = math.Max(m, math.Abs(r[j]))
+	}
+	return m
+}
+
+func TestGenerateTOTPStraightLine(t *testing.T) {
+	p := totpParams{
+		velLimits:      []float64{1, 1},
+		accelLimits:    []float64{2, 2},
+		pathTolerance:  0.1,
+		dedupTolerance: 1e-3,
+		sampleHz:       100,
+	}
+	start := []float64{0, 0}
+	samples, err := generateTOTP([][]float64{start, {2, 0}}, p)
+	test.That(t, err, test.ShouldBeNil)
+
+	// Trapezoid on joint 0: 0.5s ramp up, 1.5s cruise, 0.5s ramp down.
+	test.That(t, float64(len(samples))/p.sampleHz, test.ShouldAlmostEqual, 2.5, 0.05)
+	test.That(t, samples[len(samples)-1], test.ShouldResemble, []float64{2., 0.})
+
+	vel := finiteDiff(start, samples, p.sampleHz, 1)
+	test.That(t, maxAbs(vel, 0), test.ShouldBeLessThanOrEqualTo, 1.01)
+	test.That(t, maxAbs(vel, 1), test.ShouldEqual, 0)
+	acc := finiteDiff(start, samples, p.sampleHz, 2)
+	test.That(t, maxAbs(acc, 0), test.ShouldBeLessThanOrEqualTo, 2.1)
+}
+
+func TestGenerateTOTPBlendsCorners(t *testing.T) {
+	p := totpParams{
+		velLimits:      []float64{1, 1},
+		accelLimits:    []float64{2, 2},
+		pathTolerance:  0.05,
+		dedupTolerance: 1e-3,
+		sampleHz:       100,
+	}
+	start := []float64{0, 0}
+	corner := []float64{1, 0}
+	samples, err := generateTOTP([][]float64{start, corner, {1, 1}}, p)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, samples[len(samples)-1], test.ShouldResemble, []float64{1., 1.})
+
+	// The path never reaches the corner, but passes within the tolerance of it.
+	closest := math.Inf(1)
+	for _, s := range samples {
+		closest = math.Min(closest, vecNorm(vecSub(s, corner)))
+	}
+	test.That(t, closest, test.ShouldBeGreaterThan, 0.01)
+	test.That(t, closest, test.ShouldBeLessThanOrEqualTo, 0.05+0.01)
+
+	// Velocity is continuous through the blend, so neither joint ever exceeds its limits.
+	vel := finiteDiff(start, samples, p.sampleHz, 1)
+	acc := finiteDiff(start, samples, p.sampleHz, 2)
+	for j := range 2 {
+		test.That(t, maxAbs(vel, j), test.ShouldBeLessThanOrEqualTo, 1.01)
+		test.That(t, maxAbs(acc, j), test.ShouldBeLessThanOrEqualTo, 2.2)
+	}
+}
+
+func TestGenerateTOTPJerkLimit(t *testing.T) {
+	p := totpParams{
+		velLimits:      []float64{1},
+		accelLimits:    []float64{2},
+		pathTolerance:  0.1,
+		dedupTolerance: 1e-3,
+		sampleHz:       100,
+	}
+	start := []float64{0}
+	waypoints := [][]float64{start, {1}}
+	unlimited, err := generateTOTP(waypoints, p)
+	test.That(t, err, test.ShouldBeNil)
+
+	p.jerkLimits = []float64{50}
+	limited, err := generateTOTP(waypoints, p)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(limited), test.ShouldBeGreaterThan, len(unlimited))
+	test.That(t, maxJerkRatio(start, limited, p.jerkLimits, p.sampleHz), test.ShouldBeLessThanOrEqualTo, 1)
+}
+
+func TestGenerateTOTPNoMotion(t *testing.T) {
+	p := totpParams{
+		velLimits:      []float64{1, 1},
+		accelLimits:    []float64{1, 1},
+		pathTolerance:  0.1,
+		dedupTolerance: 1e-3,
+		sampleHz:       100,
+	}
+	samples, err := generateTOTP([][]float64{{0.5, 0.5}, {0.5, 0.5005}, {0.5, 0.5}}, p)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, samples, test.ShouldBeNil)
+
+	_, err = generateTOTP([][]float64{{0, 0}, {1}}, p)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	p.velLimits = []float64{1}
+	_, err = generateTOTP([][]float64{{0, 0}, {1, 1}}, p)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestTrajGenConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  TrajGenConfig
+		ok   bool
+	}{
+		{"service only", TrajGenConfig{Service: "trajex"}, true},
+		{"builtin with limits", TrajGenConfig{Builtin: true, JointVelocityLimits: []float64{90, 90}, JointJerkLimits: []float64{5000}}, true},
+		{"service and builtin", TrajGenConfig{Service: "trajex", Builtin: true}, false},
+		{"limits without builtin", TrajGenConfig{Service: "trajex", JointVelocityLimits: []float64{90}}, false},
+		{"non-positive limit", TrajGenConfig{Builtin: true, JointAccelerationLimits: []float64{0}}, false},
+		{"velocity above arm max", TrajGenConfig{Builtin: true, JointVelocityLimits: []float64{maxSpeed + 1}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.validate()
+			if tc.ok {
+				test.That(t, err, test.ShouldBeNil)
+			} else {
+				test.That(t, err, test.ShouldNotBeNil)
+			}
+		})
+	}
+}
+
+func TestDedupWaypointsKeepsGoal(t *testing.T) {
+	// The goal is within tolerance of the waypoint before it, and replaces it.
+	out := dedupWaypoints([][]float64{{0}, {1}, {1.0005}}, 1e-3)
+	test.That(t, out, test.ShouldResemble, [][]float64{{0}, {1.0005}})
+
+	out = dedupWaypoints([][]float64{{0}, {0.5}, {1}}, 1e-3)
+	test.That(t, out, test.ShouldResemble, [][]float64{{0}, {0.5}, {1}})
+
+	// A goal within tolerance of the start leaves nowhere to go.
+	out = dedupWaypoints([][]float64{{0}, {0.0005}}, 1e-3)
+	test.That(t, out, test.ShouldResemble, [][]float64{{0}})
+}
@@ -16,6 +16,7 @@ import (
 	"go.uber.org/multierr"
 	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
@@ -24,6 +25,7 @@ import (
 	"go.viam.com/rdk/services/motion"
 	"go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/utils"
+	goutils "go.viam.com/utils"
 )
 
 const (
@@ -39,6 +41,8 @@ const (
 
 	interwaypointAccel = 600. // degrees per second per second. All xarms max out at 1145
 
+	defaultJerk = defaultAccel * 10 // degrees per second per second per second; reaches defaultAccel in 100ms
+
 	defaultTrajGenPathToleranceDeltaRads             = 0.1
 	defaultTrajGenWaypointDeduplicationToleranceRads = 1e-3
 
@@ -68,6 +72,13 @@ const (
 	ftSensorZeroKey          = "ft_sensor_zero"
 	ftSensorEnableKey        = "ft_sensor_enable"
 	ftSensorDataKey          = "ft_sensor_data"
+	planOnlyKey              = "plan_only"
+	getJointTelemetryKey     = "get_joint_telemetry"
+	jointTelemetryKey        = "joint_telemetry"
+	getServoErrorsKey        = "get_servo_errors"
+	servoErrorsKey           = "servo_errors"
+	controllerErrorKey       = "controller_error"
+	statusErrorKey           = "status_error"
 
 	// gripperLiteActionKeys.
 	gripperLiteActionOpen     = "open"
@@ -153,6 +164,15 @@ type xArm struct {
 	motion      motion.Service
 	trajGen     mlmodel.Service
 	proxyServer *http.Server
+	workers     *goutils.StoppableWorkers
+	ops         operationTracker
+	recovery    recoveryState
+	events      *eventLog
+	reduced     reducedModeState
+	overrides   overrideTracker
+
+	// reducedModeSensor turns reduced mode on and off, if reduced_mode names a sensor.
+	reducedModeSensor sensor.Sensor
 
 	// below is all configuration things
 	dof    int
@@ -163,6 +183,12 @@ type xArm struct {
 	confLock     sync.Mutex // speed and acceleration are both able to be read/written to, so they need to be protected by a mutex
 	speed        float64    // speed=max joint radians per second
 	acceleration float64    // acceleration= joint radians per second increase per second
+	profile      string     // motion profile used to interpolate moves, see scurve.go
+	jerk         float64    // jerk= joint radians per second per second increase per second
+	jointJerks   []float64  // per-joint jerk overrides in radians per second cubed, may be empty
+	blendRadius  float64    // millimetres the controller may round off intermediate waypoints of direct moves by
+
+	cableRanges []cableRange // joints whose angles are resolved within their cable range, see cable.go
 
 	// gripperControlMode records whether the gripper's FnCxx block-write control mode may be
 	// enabled. Only graspWithTorque turns it on, but it survives a process restart, so it starts
@@ -171,6 +197,9 @@ type xArm struct {
 	// gripperSpeed is the last speed written to the gripper's Fn303 register, or 0 if none was
 	// ever set. Clearing the FnCxx control mode resets Fn303, so we need this to put it back.
 	gripperSpeed atomic.Uint32
+	// rs485BaudLock serialises checking and switching the RS-485 bus baud rates between the devices
+	// on them.
+	rs485BaudLock sync.Mutex
 
 	detectedArm detectedArm
 }
@@ -198,12 +227,51 @@ func register(model resource.Model) {
 	)
 }
 
-// TrajGenConfig holds configuration for the trajectory generator ML model service.
+// TrajGenConfig holds configuration for the trajectory generator. Either Service names an ML model
+// service to call, or Builtin selects the in-process time-optimal generator in totp.go.
 type TrajGenConfig struct {
 	Service                            string   `json:"service"`
+	Builtin                            bool     `json:"builtin,omitempty"`
 	PathToleranceDeltaRads             *float64 `json:"path_tolerance_delta_rads,omitempty"`
 	PathColinearizationRatio           *float64 `json:"path_colinearization_ratio,omitempty"`
 	WaypointDeduplicationToleranceRads *float64 `json:"waypoint_deduplication_tolerance_rads,omitempty"`
+
+	// Per-joint limits for the builtin generator. Each is optional; when set it must have one entry per joint.
+	// Velocity and acceleration limits are additionally capped by the arm's speed and acceleration for the move.
+	JointVelocityLimits     []float64 `json:"joint_velocity_limits_degs_per_sec,omitempty"`
+	JointAccelerationLimits []float64 `json:"joint_acceleration_limits_degs_per_sec_per_sec,omitempty"`
+	JointJerkLimits         []float64 `json:"joint_jerk_limits_degs_per_sec_per_sec_per_sec,omitempty"`
+}
+
+func (cfg *TrajGenConfig) validate() error {
+	if cfg.Service != "" && cfg.Builtin {
+		return errors.New("trajectory_generator cannot set both service and builtin")
+	}
+	if !cfg.Builtin && (len(cfg.JointVelocityLimits) > 0 || len(cfg.JointAccelerationLimits) > 0 || len(cfg.JointJerkLimits) > 0) {
+		return errors.New("trajectory_generator joint limits are only used by the builtin generator")
+	}
+	for name, limits := range map[string][]float64{
+		"joint_velocity_limits_degs_per_sec":             cfg.JointVelocityLimits,
+		"joint_acceleration_limits_degs_per_sec_per_sec": cfg.JointAccelerationLimits,
+		"joint_jerk_limits_degs_per_sec_per_sec_per_sec": cfg.JointJerkLimits,
+	} {
+		for i, v := range limits {
+			if v <= 0 {
+				return fmt.Errorf("trajectory_generator %s[%d] must be positive, got %f", name, i, v)
+			}
+		}
+	}
+	for i, v := range cfg.JointVelocityLimits {
+		if v > maxSpeed {
+			return fmt.Errorf("trajectory_generator joint_velocity_limits_degs_per_sec[%d] cannot be more than %f", i, maxSpeed)
+		}
+	}
+	for i, v := range cfg.JointAccelerationLimits {
+		if v > maxAccel {
+			return fmt.Errorf("trajectory_generator joint_acceleration_limits_degs_per_sec_per_sec[%d] cannot be more than %f", i, maxAccel)
+		}
+	}
+	return nil
 }
 
 // Config is used for converting config attributes.
@@ -214,12 +282,25 @@ type Config struct {
 	Acceleration         float64        `json:"acceleration_degs_per_sec_per_sec,omitempty"`
 	MoveHZ               float64        `json:"move_hz,omitempty"`
 	Sensitivity          *int           `json:"collision_sensitivity,omitempty"`
+	MotionProfile        string         `json:"motion_profile,omitempty"`
+	Jerk                 float64        `json:"jerk_degs_per_sec_per_sec_per_sec,omitempty"`
+	JointJerks           []float64      `json:"joint_jerks_degs_per_sec_per_sec_per_sec,omitempty"`
+	BlendRadius          float64        `json:"blend_radius_mm,omitempty"`
 	BadJoints            []int          `json:"bad-joints"`
 	Motion               string         `json:"motion"`
 	UseURDFs             bool           `json:"use_urdfs,omitempty"`
 	TrajGen              *TrajGenConfig `json:"trajectory_generator,omitempty"`
 	MeshDecimationRatios []float64      `json:"mesh_decimation_ratios,omitempty"`
 
+	JointLimits                 []JointLimitConfig `json:"joint_limits,omitempty"`
+	CableRanges                 []JointLimitConfig `json:"cable_ranges,omitempty"`
+	PushJointLimitsToController bool               `json:"push_joint_limits_to_controller,omitempty"`
+	ReducedMode                 *ReducedModeConfig `json:"reduced_mode,omitempty"`
+	Payload                     *PayloadConfig     `json:"payload,omitempty"`
+
+	ErrorRecovery *ErrorRecoveryConfig `json:"error_recovery,omitempty"`
+	EventLogSize  int                  `json:"event_log_size,omitempty"`
+
 	StudioProxy     bool `json:"ufactory-studio-proxy,omitempty"`
 	StudioProxyPort int  `json:"ufactory-studio-proxy-port,omitempty"`
 }
@@ -249,12 +330,51 @@ func (cfg *Config) Validate(path string) ([]string, []string, error) {
 		return nil, nil, fmt.Errorf("given collision sensitivity %d is invalid, must be 0-5", cfg.Sensitivity)
 	}
 
+	if cfg.Payload != nil {
+		if err := cfg.Payload.validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if cfg.MotionProfile != "" && cfg.MotionProfile != motionProfileTrapezoidal && cfg.MotionProfile != motionProfileSCurve {
+		return nil, nil, fmt.Errorf("motion_profile must be %q or %q, got %q", motionProfileTrapezoidal, motionProfileSCurve, cfg.MotionProfile)
+	}
+
+	if cfg.Jerk < 0 {
+		return nil, nil, fmt.Errorf("given jerk %f cannot be negative", cfg.Jerk)
+	}
+
+	if cfg.BlendRadius < 0 {
+		return nil, nil, fmt.Errorf("given blend radius %f cannot be negative", cfg.BlendRadius)
+	}
+
+	for i, j := range cfg.JointJerks {
+		if j <= 0 {
+			return nil, nil, fmt.Errorf("joint_jerks_degs_per_sec_per_sec_per_sec[%d] must be positive, got %f", i, j)
+		}
+	}
+
 	for i, r := range cfg.MeshDecimationRatios {
 		if r < 0 || r > 1 {
 			return nil, nil, fmt.Errorf("mesh_decimation_ratios[%d] must be in [0, 1], got %f", i, r)
 		}
 	}
 
+	limited := map[int]bool{}
+	for _, j := range cfg.BadJoints {
+		limited[j] = true
+	}
+	if err := validateJointLimits("joint_limits", cfg.JointLimits, limited); err != nil {
+		return nil, nil, err
+	}
+	if err := validateJointLimits("cable_ranges", cfg.CableRanges, limited); err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.PushJointLimitsToController && len(cfg.JointLimits) == 0 && len(cfg.CableRanges) == 0 {
+		return nil, nil, errors.New("push_joint_limits_to_controller needs joint_limits or cable_ranges")
+	}
+
 	deps := []string{}
 	opt := []string{}
 
@@ -264,12 +384,70 @@ func (cfg *Config) Validate(path string) ([]string, []string, error) {
 		opt = append(opt, motion.Named("builtin").String())
 	}
 
-	if cfg.TrajGen != nil && cfg.TrajGen.Service != "" {
-		deps = append(deps, cfg.TrajGen.Service)
+	if cfg.ReducedMode != nil {
+		if err := cfg.ReducedMode.validate(); err != nil {
+			return nil, nil, err
+		}
+		if cfg.ReducedMode.Sensor != "" {
+			deps = append(deps, cfg.ReducedMode.Sensor)
+		}
+	}
+
+	if cfg.EventLogSize < 0 {
+		return nil, nil, fmt.Errorf("given event log size %d cannot be negative", cfg.EventLogSize)
+	}
+
+	if cfg.ErrorRecovery != nil {
+		if err := cfg.ErrorRecovery.validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if cfg.TrajGen != nil {
+		if err := cfg.TrajGen.validate(); err != nil {
+			return nil, nil, err
+		}
+		if cfg.TrajGen.Service != "" {
+			deps = append(deps, cfg.TrajGen.Service)
+		}
 	}
 	return deps, opt, nil
 }
 
+// useBuiltinTrajGen reports whether moves should be planned by the builtin time-optimal generator.
+func (cfg *Config) useBuiltinTrajGen() bool {
+	return cfg != nil && cfg.TrajGen != nil && cfg.TrajGen.Builtin
+}
+
+func (cfg *Config) eventLogSize() int {
+	if cfg.EventLogSize == 0 {
+		return defaultEventLogSize
+	}
+	return cfg.EventLogSize
+}
+
+func (cfg *Config) motionProfile() string {
+	if cfg.MotionProfile == "" {
+		return motionProfileTrapezoidal
+	}
+	return cfg.MotionProfile
+}
+
+func (cfg *Config) jerk() float64 {
+	if cfg.Jerk == 0 {
+		return defaultJerk
+	}
+	return cfg.Jerk
+}
+
+func (cfg *Config) jointJerks() []float64 {
+	jerks := make([]float64, len(cfg.JointJerks))
+	for i, j := range cfg.JointJerks {
+		jerks[i] = utils.DegToRad(j)
+	}
+	return jerks
+}
+
 func (cfg *Config) speed() float32 {
 	if cfg.Speed == 0 {
 		return defaultSpeed
@@ -408,10 +586,22 @@ func NewXArm(ctx context.Context, name resource.Name,
 
 		acceleration: utils.DegToRad(float64(newConf.acceleration())),
 		speed:        utils.DegToRad(float64(newConf.speed())),
+		profile:      newConf.motionProfile(),
+		jerk:         utils.DegToRad(newConf.jerk()),
+		jointJerks:   newConf.jointJerks(),
+		blendRadius:  newConf.BlendRadius,
+		cableRanges:  newCableRanges(newConf.CableRanges),
+		workers:      goutils.NewBackgroundStoppableWorkers(),
 	}
 	x.cmdConn = newModbusConn(newConf.host(), logger, func() { x.started.Store(-1) })
 	x.gripperConn = x.cmdConn // overwritten below if port 503 connects
 	x.gripperControlMode.Store(true)
+	x.recovery.policy = newRecoveryPolicy(newConf.ErrorRecovery)
+	x.events = newEventLog(eventLogPath(name.ShortName()), newConf.eventLogSize(), logger)
+	x.cmdConn.onReconnect = func() {
+		x.events.add(armEvent{Type: eventTypeReconnect, Message: "reconnected to the controller at " + newConf.host()})
+	}
+	x.cmdConn.onControllerError = x.recordControllerError
 
 	if newConf.Motion != "" {
 		if deps == nil {
@@ -428,11 +618,20 @@ func NewXArm(ctx context.Context, name resource.Name,
 		}
 	}
 
-	if newConf.TrajGen != nil && newConf.TrajGen.Service != "" {
-		x.trajGen, err = mlmodel.FromProvider(deps, newConf.TrajGen.Service)
+	if newConf.ReducedMode != nil && newConf.ReducedMode.Sensor != "" {
+		x.reducedModeSensor, err = sensor.FromProvider(deps, newConf.ReducedMode.Sensor)
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	if newConf.TrajGen != nil && (newConf.TrajGen.Service != "" || newConf.TrajGen.Builtin) {
+		if newConf.TrajGen.Service != "" {
+			x.trajGen, err = mlmodel.FromProvider(deps, newConf.TrajGen.Service)
+			if err != nil {
+				return nil, err
+			}
+		}
 		if newConf.TrajGen.PathToleranceDeltaRads == nil {
 			v := defaultTrajGenPathToleranceDeltaRads
 			newConf.TrajGen.PathToleranceDeltaRads = &v
@@ -516,8 +715,28 @@ func NewXArm(ctx context.Context, name resource.Name,
 	if err != nil {
 		return nil, err
 	}
+	x.model, err = applyJointLimits(x.model, append(append([]JointLimitConfig(nil), newConf.JointLimits...), newConf.CableRanges...))
+	if err != nil {
+		return nil, multierr.Combine(err, x.Close(ctx))
+	}
 	x.dof = len(x.model.DoF())
 
+	if len(newConf.JointJerks) != 0 && len(newConf.JointJerks) != x.dof {
+		err := fmt.Errorf("joint_jerks_degs_per_sec_per_sec_per_sec needs %d entries for this arm, got %d", x.dof, len(newConf.JointJerks))
+		return nil, multierr.Combine(err, x.Close(ctx))
+	}
+
+	if newConf.useBuiltinTrajGen() {
+		for _, limits := range [][]float64{
+			newConf.TrajGen.JointVelocityLimits, newConf.TrajGen.JointAccelerationLimits, newConf.TrajGen.JointJerkLimits,
+		} {
+			if len(limits) != 0 && len(limits) != x.dof {
+				err := fmt.Errorf("trajectory_generator joint limits need %d entries for this arm, got %d", x.dof, len(limits))
+				return nil, multierr.Combine(err, x.Close(ctx))
+			}
+		}
+	}
+
 	if len(current) > 0 {
 		logger.Infof("model that was loaded config")
 		for j, jc := range x.model.ModelConfig().Joints {
@@ -532,6 +751,24 @@ func NewXArm(ctx context.Context, name resource.Name,
 		}
 	}
 
+	if newConf.Payload != nil {
+		if err := x.setPayload(ctx, *newConf.Payload); err != nil {
+			return nil, multierr.Combine(err, x.Close(ctx))
+		}
+	}
+
+	if newConf.PushJointLimitsToController {
+		if err := x.pushJointLimits(ctx); err != nil {
+			return nil, multierr.Combine(fmt.Errorf("pushing joint_limits to the controller: %w", err), x.Close(ctx))
+		}
+	}
+
+	if newConf.ReducedMode != nil {
+		if err := x.setupReducedMode(ctx, newConf.ReducedMode); err != nil {
+			return nil, multierr.Combine(err, x.Close(ctx))
+		}
+	}
+
 	if newConf.StudioProxy {
 		if err := x.startProxy(ctx); err != nil {
 			return nil, multierr.Combine(err, x.Close(ctx))
@@ -545,10 +782,37 @@ type moveOptions struct {
 	speed        float64
 	acceleration float64
 	moveHZ       float64
+	profile      string
+	jerk         float64
+	jointJerks   []float64
+	// jerkSet is whether the config or extra asks for a jerk, by setting one or choosing the s-curve
+	// profile. Without it, direct moves leave the controller's own jerk alone.
+	jerkSet     bool
+	blendRadius float64
+	operationID string
 
 	direct      bool
 	waitAtEnd   bool
 	interpolate bool
+	planOnly    bool
+
+	// sensitivity and payload override the controller's settings for the move, see move_overrides.go.
+	// overridesErr refuses the move when extra asks for an override that can't be made.
+	sensitivity  *int
+	payload      *PayloadConfig
+	overridesErr error
+}
+
+// jerks returns the jerk limit of each of `dof` joints, in radians per second cubed.
+func (mo moveOptions) jerks(dof int) []float64 {
+	jerks := make([]float64, dof)
+	for j := range jerks {
+		jerks[j] = mo.jerk
+		if j < len(mo.jointJerks) {
+			jerks[j] = mo.jointJerks[j]
+		}
+	}
+	return jerks
 }
 
 func f64(extra map[string]any, n string) (float64, bool) {
@@ -575,10 +839,15 @@ func (x *xArm) moveOptions(opts *arm.MoveOptions, extra map[string]any) moveOpti
 		speed:        x.speed,
 		acceleration: x.acceleration,
 		moveHZ:       x.moveHZ,
+		profile:      x.profile,
+		jerk:         x.jerk,
+		jointJerks:   x.jointJerks,
+		blendRadius:  x.blendRadius,
 		direct:       false,
 		waitAtEnd:    true,
 		interpolate:  true,
 	}
+	o.jerkSet = x.profile == motionProfileSCurve || (x.conf != nil && (x.conf.Jerk != 0 || len(x.conf.JointJerks) > 0))
 
 	if opts != nil {
 		if opts.MaxVelRads != 0 {
@@ -611,6 +880,39 @@ func (x *xArm) moveOptions(opts *arm.MoveOptions, extra map[string]any) moveOpti
 			o.acceleration = utils.DegToRad(v)
 		}
 
+		// A single jerk from extra applies to every joint, replacing any per-joint configuration. As
+		// with speed and acceleration, jerk_d wins over jerk_r.
+		jerk, ok := f64(extra, "jerk_r")
+		if jerkD, okD := f64(extra, "jerk_d"); okD {
+			jerk, ok = utils.DegToRad(jerkD), true
+		}
+		if ok {
+			if jerk > 0 {
+				o.jerk = jerk
+				o.jointJerks = nil
+				o.jerkSet = true
+			} else {
+				x.logger.Warnf("invalid jerk option %.2f: must be positive, ignoring", jerk)
+			}
+		}
+
+		if profile, ok := extra["motion_profile"].(string); ok {
+			if profile == motionProfileTrapezoidal || profile == motionProfileSCurve {
+				o.profile = profile
+				o.jerkSet = o.jerkSet || profile == motionProfileSCurve
+			} else {
+				x.logger.Warnf("invalid motion_profile option %q: using %q", profile, o.profile)
+			}
+		}
+
+		if radius, ok := f64(extra, "blend_radius_mm"); ok {
+			if radius >= 0 {
+				o.blendRadius = radius
+			} else {
+				x.logger.Warnf("invalid blend_radius_mm option %.2f: cannot be negative, ignoring", radius)
+			}
+		}
+
 		if extra["direct"] == true {
 			o.direct = true
 		}
@@ -622,6 +924,16 @@ func (x *xArm) moveOptions(opts *arm.MoveOptions, extra map[string]any) moveOpti
 		if extra["interpolate"] == false {
 			o.interpolate = false
 		}
+
+		if id, ok := extra[operationIDKey].(string); ok {
+			o.operationID = id
+		}
+
+		if extra[planOnlyKey] == true {
+			o.planOnly = true
+		}
+
+		o.overridesErr = x.moveOverridesFromExtra(&o, extra)
 	}
 
 	o.speed = x.clampMoveOptions(
@@ -638,6 +950,11 @@ func (x *xArm) moveOptions(opts *arm.MoveOptions, extra map[string]any) moveOpti
 		"max acceleration",
 	)
 
+	// While reduced mode is on, the module's own plans keep to its joint speed as the controller does.
+	if limit := x.reduced.speedLimit(); limit > 0 && o.speed > limit {
+		o.speed = limit
+	}
+
 	return o
 }
 
@@ -880,11 +1197,40 @@ func (x *xArm) DoCommand(ctx context.Context, cmd map[string]any) (map[string]an
 		validCommand = true
 	}
 	if _, ok := cmd[clearErrorKey]; ok {
-		if err := x.checkReadyState(ctx, false); err != nil {
+		if err := x.clearErrors(ctx); err != nil {
 			return nil, err
 		}
 		validCommand = true
 	}
+	if _, ok := cmd[inventoryKey]; ok {
+		return x.inventory(ctx)
+	}
+	if val, ok := cmd[getEventLogKey]; ok {
+		return x.eventLogCommand(val)
+	}
+	if _, ok := cmd[getRecoveryStatusKey]; ok {
+		resp[getRecoveryStatusKey] = x.recovery.toMap()
+		validCommand = true
+	}
+	if val, ok := cmd[setReducedModeKey]; ok {
+		active, ok := val.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a bool, got %T", setReducedModeKey, val)
+		}
+		if err := x.setReducedMode(ctx, active, reducedModeSourceManual); err != nil {
+			return nil, err
+		}
+		resp[reducedModeKey] = active
+		validCommand = true
+	}
+	if _, ok := cmd[getReducedModeKey]; ok {
+		m, err := x.getReducedModeCommand(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp[getReducedModeKey] = m
+		validCommand = true
+	}
 	if _, ok := cmd[getStateKey]; ok {
 		c := x.newCmd(regMap["GetState"])
 		sData, err := x.send(ctx, c, true)
@@ -900,7 +1246,18 @@ func (x *xArm) DoCommand(ctx context.Context, cmd map[string]any) (map[string]an
 			return nil, err
 		}
 
-		return map[string]any{"error info": sData.params}, nil
+		resp := map[string]any{"error info": sData.params}
+		if len(sData.params) >= 3 && (sData.params[1] != 0 || sData.params[2] != 0) {
+			resp[controllerErrorKey] = newControllerError(sData.params[0], sData.params[1], sData.params[2]).toMap()
+		}
+		return resp, nil
+	}
+	if _, ok := cmd[getServoErrorsKey]; ok {
+		errs, err := x.servoErrorsCommand(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{servoErrorsKey: errs}, nil
 	}
 	if _, ok := cmd[getVacuumGripperStateKey]; ok {
 		ct := connectionTypeFromCmd(cmd, vacuumGripperSubmodel(x.detectedArm))
@@ -941,6 +1298,15 @@ func (x *xArm) DoCommand(ctx context.Context, cmd map[string]any) (map[string]an
 		validCommand = true
 	}
 
+	if _, ok := cmd[getJointTelemetryKey]; ok {
+		t, err := x.getJointTelemetry(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp[jointTelemetryKey] = jointTelemetryReadingsMap(t)
+		validCommand = true
+	}
+
 	if _, ok := cmd[getFTSensorDataKey]; ok {
 		vals, err := x.getFTSensorData(ctx)
 		if err != nil {
@@ -962,6 +1328,42 @@ func (x *xArm) DoCommand(ctx context.Context, cmd map[string]any) (map[string]an
 		validCommand = true
 	}
 
+	if val, ok := cmd[waitForOperationKey]; ok {
+		op, err := x.waitForOperationCommand(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		resp[operationKey] = op
+		validCommand = true
+	}
+	if val, ok := cmd[getOperationKey]; ok {
+		id, err := operationIDFromCmd(val)
+		if err != nil {
+			return nil, err
+		}
+		op, err := x.ops.get(id)
+		if err != nil {
+			return nil, err
+		}
+		resp[operationKey] = op.toMap()
+		validCommand = true
+	}
+	if _, ok := cmd[flushCommandQueueKey]; ok {
+		if err := x.flushCommandQueue(ctx); err != nil {
+			return nil, err
+		}
+		validCommand = true
+	}
+
+	if val, ok := cmd[planOnlyKey]; ok {
+		plan, err := x.planOnlyCommand(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		resp[planOnlyKey] = plan
+		validCommand = true
+	}
+
 	if !validCommand {
 		return nil, errors.New("command not found")
 	}
@@ -972,6 +1374,39 @@ func (x *xArm) Name() resource.Name {
 	return x.name
 }
 
-func (x *xArm) Status(_ context.Context) (map[string]any, error) {
-	return map[string]any{}, nil
+// Status reports the controller's queue and servo faults alongside what the module knows itself. If
+// the controller can't be read, the module's own state is still reported, with the read error.
+func (x *xArm) Status(ctx context.Context) (map[string]any, error) {
+	pending := []any{}
+	for _, id := range x.ops.pending() {
+		pending = append(pending, id)
+	}
+	status := map[string]any{
+		pendingOperationsKey: pending,
+		lastOperationIDKey:   x.ops.last(),
+		reducedModeKey:       x.reduced.toMap()[reducedModeKey],
+	}
+
+	var readErr error
+	if cmdCount, err := x.getCmdCount(ctx); err != nil {
+		readErr = fmt.Errorf("reading the command count: %w", err)
+	} else {
+		status[cmdCountKey] = float64(cmdCount)
+	}
+	if servoErrs, err := x.jointServoErrors(ctx); err != nil {
+		readErr = multierr.Combine(readErr, fmt.Errorf("reading the servo errors: %w", err))
+	} else {
+		// Only faulted joints are listed, so a healthy arm reports an empty list.
+		faults := []any{}
+		for _, e := range servoErrs {
+			if e.faulted() {
+				faults = append(faults, e.toMap())
+			}
+		}
+		status[servoErrorsKey] = faults
+	}
+	if readErr != nil {
+		status[statusErrorKey] = readErr.Error()
+	}
+	return status, nil
 }
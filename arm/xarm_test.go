@@ -242,6 +242,38 @@ func TestMoveOptions(t *testing.T) {
 	test.That(t, mo.speed, test.ShouldEqual, math.Pi/2)
 	test.That(t, mo.acceleration, test.ShouldEqual, base.acceleration)
 	test.That(t, mo.moveHZ, test.ShouldEqual, base.moveHZ)
+
+	x.profile = motionProfileTrapezoidal
+	x.jerk = 10
+	x.jointJerks = []float64{1, 2}
+	mo = x.moveOptions(nil, nil)
+	test.That(t, mo.profile, test.ShouldEqual, motionProfileTrapezoidal)
+	test.That(t, mo.jerks(3), test.ShouldResemble, []float64{1, 2, 10})
+	// Nothing configured a jerk, so direct moves keep the controller's own.
+	test.That(t, mo.jerkSet, test.ShouldBeFalse)
+	x.conf = &Config{JointJerks: []float64{57, 114}}
+	test.That(t, x.moveOptions(nil, nil).jerkSet, test.ShouldBeTrue)
+	x.conf = nil
+	test.That(t, x.moveOptions(nil, map[string]any{"motion_profile": "s_curve"}).jerkSet, test.ShouldBeTrue)
+	test.That(t, x.moveOptions(nil, map[string]any{"jerk_r": -1.0}).jerkSet, test.ShouldBeFalse)
+
+	mo = x.moveOptions(nil, map[string]any{"motion_profile": "s_curve", "jerk_d": 180})
+	test.That(t, mo.profile, test.ShouldEqual, motionProfileSCurve)
+	test.That(t, mo.jerks(2), test.ShouldResemble, []float64{math.Pi, math.Pi})
+	test.That(t, mo.jerkSet, test.ShouldBeTrue)
+	// jerk_d wins over jerk_r, as speed_d and acceleration_d do.
+	mo = x.moveOptions(nil, map[string]any{"jerk_r": 1.0, "jerk_d": 180})
+	test.That(t, mo.jerks(1), test.ShouldResemble, []float64{math.Pi})
+
+	x.blendRadius = 5
+	mo = x.moveOptions(nil, map[string]any{"blend_radius_mm": 20.0})
+	test.That(t, mo.blendRadius, test.ShouldEqual, 20)
+	mo = x.moveOptions(nil, map[string]any{"blend_radius_mm": -1.0})
+	test.That(t, mo.blendRadius, test.ShouldEqual, 5)
+
+	mo = x.moveOptions(nil, map[string]any{"motion_profile": "bogus", "jerk_r": -1.0})
+	test.That(t, mo.profile, test.ShouldEqual, motionProfileTrapezoidal)
+	test.That(t, mo.jerks(2), test.ShouldResemble, []float64{1, 2})
 }
 
 func TestFTReadingsMap(t *testing.T) {
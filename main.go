@@ -4,6 +4,7 @@ package main
 import (
 	xarm "github.com/viam-modules/viam-ufactory-xarm/arm"
 	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/gantry"
 	"go.viam.com/rdk/components/gripper"
 	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/module"
@@ -18,8 +19,15 @@ func main() {
 		resource.APIModel{API: arm.API, Model: xarm.XArm850Model},
 		resource.APIModel{API: gripper.API, Model: xarm.GripperModel},
 		resource.APIModel{API: gripper.API, Model: xarm.GripperModelLite},
+		resource.APIModel{API: gripper.API, Model: xarm.BioGripperModel},
+		resource.APIModel{API: gripper.API, Model: xarm.Robotiq2FModel},
 		resource.APIModel{API: gripper.API, Model: xarm.VacuumGripperModel},
 		resource.APIModel{API: gripper.API, Model: xarm.VacuumGripperModelLite},
 		resource.APIModel{API: sensor.API, Model: xarm.FTSensorModel},
+		resource.APIModel{API: sensor.API, Model: xarm.JointTelemetryModel},
+		resource.APIModel{API: sensor.API, Model: xarm.ToolModbusModel},
+		resource.APIModel{API: sensor.API, Model: xarm.ControlBoxModbusModel},
+		resource.APIModel{API: gantry.API, Model: xarm.LinearTrackModel},
+		resource.APIModel{API: arm.API, Model: xarm.LinearTrackModel},
 	)
 }